@@ -0,0 +1,177 @@
+package symbolset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// symbol aliases and deprecated symbols
+
+// Alias is an alternative spelling of a symbol (or of a symbol's IPA string), that is accepted as input and can be normalized into the canonical form.
+// Aliases are declared in the .sym file using ALIAS or DEPRECATED lines, with the symbol type (SYMBOLS or IPA), the alias and the canonical form:
+//
+//	ALIAS	IPA	g	ɡ
+//	DEPRECATED	SYMBOLS	s'	s
+//
+// Aliases are not valid symbols (see SymbolSet.ValidSymbol), so transcriptions containing aliases will not pass validation until they have been normalized.
+type Alias struct {
+	// Alias is the alternative spelling
+	Alias string
+
+	// Canonical is the canonical form (a symbol string, or an IPA string if IPA is true)
+	Canonical string
+
+	// IPA is true for aliases of IPA strings, false for aliases of symbol strings
+	IPA bool
+
+	// Deprecated is true for legacy spellings that should not be used in new data
+	Deprecated bool
+}
+
+func (a Alias) String() string {
+	fs := []string{"ALIAS", "SYMBOLS", a.Alias, a.Canonical}
+	if a.Deprecated {
+		fs[0] = "DEPRECATED"
+	}
+	if a.IPA {
+		fs[1] = "IPA"
+	}
+	return strings.Join(fs, "\t")
+}
+
+func isAliasLine(l string) bool {
+	return strings.HasPrefix(l, "ALIAS\t") || strings.HasPrefix(l, "DEPRECATED\t")
+}
+
+func parseAliasLine(l string) (Alias, error) {
+	fs := strings.Split(l, "\t")
+	if len(fs) != 4 {
+		return Alias{}, fmt.Errorf("alias line must have 4 fields, found %s", l)
+	}
+	if fs[1] != "SYMBOLS" && fs[1] != "IPA" {
+		return Alias{}, fmt.Errorf("invalid symbol type %s for alias line %s", fs[1], l)
+	}
+	return Alias{
+		Alias:      fs[2],
+		Canonical:  fs[3],
+		IPA:        fs[1] == "IPA",
+		Deprecated: fs[0] == "DEPRECATED",
+	}, nil
+}
+
+// buildAliasMaps checks the aliases against the symbols, and returns one alias map for symbol strings and one for IPA strings
+func buildAliasMaps(symbols []Symbol, aliases []Alias) (map[string]Alias, map[string]Alias, error) {
+	symbolAliases := make(map[string]Alias)
+	ipaAliases := make(map[string]Alias)
+	for _, a := range aliases {
+		if len(a.Alias) == 0 {
+			return nil, nil, fmt.Errorf("empty alias for /%s/", a.Canonical)
+		}
+		aliasMap := symbolAliases
+		canonicalExists, aliasExists := false, false
+		for _, s := range symbols {
+			str := s.String
+			if a.IPA {
+				str = s.IPA.String
+			}
+			if str == a.Canonical {
+				canonicalExists = true
+			}
+			if str == a.Alias {
+				aliasExists = true
+			}
+		}
+		if a.IPA {
+			aliasMap = ipaAliases
+		}
+		if !canonicalExists {
+			return nil, nil, fmt.Errorf("canonical symbol /%s/ for alias /%s/ is not defined", a.Canonical, a.Alias)
+		}
+		if aliasExists {
+			return nil, nil, fmt.Errorf("alias /%s/ is already defined as a symbol", a.Alias)
+		}
+		if _, exists := aliasMap[a.Alias]; exists {
+			return nil, nil, fmt.Errorf("alias /%s/ is defined more than once", a.Alias)
+		}
+		aliasMap[a.Alias] = a
+	}
+	return symbolAliases, ipaAliases, nil
+}
+
+// Normalization is a change made when normalizing a transcription
+type Normalization struct {
+	// Index is the position of the symbol in the splitted transcription
+	Index int
+
+	// From is the input alias
+	From string
+
+	// To is the canonical form
+	To string
+
+	// Deprecated is true if the input alias is deprecated
+	Deprecated bool
+}
+
+func (n Normalization) String() string {
+	if n.Deprecated {
+		return fmt.Sprintf("%d: /%s/ => /%s/ (deprecated)", n.Index, n.From, n.To)
+	}
+	return fmt.Sprintf("%d: /%s/ => /%s/", n.Index, n.From, n.To)
+}
+
+// Normalize rewrites aliases and deprecated symbols in the input transcription into their canonical form.
+// It returns the normalized transcription, along with the changes that were made.
+// Symbols that are neither symbols nor aliases are reported as unknown.
+func (ss SymbolSet) Normalize(trans string) (string, []Normalization, error) {
+	splitted, err := ss.SplitTranscription(trans)
+	if err != nil {
+		return "", nil, err
+	}
+	res, changes, err := normalize(splitted, ss.symbolAliases, ss.ValidSymbol)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(changes) == 0 {
+		return trans, changes, nil
+	}
+	return strings.Join(res, ss.PhonemeDelimiter.String), changes, nil
+}
+
+// NormalizeInternalIPA rewrites IPA aliases and deprecated IPA symbols in the input IPA transcription into their canonical form.
+// It returns the normalized transcription (as filtered by SplitInternalIPATranscription), along with the changes that were made.
+func (ss SymbolSet) NormalizeInternalIPA(trans string) (string, []Normalization, error) {
+	splitted, err := ss.SplitInternalIPATranscription(trans)
+	if err != nil {
+		return "", nil, err
+	}
+	res, changes, err := normalize(splitted, ss.ipaAliases, ss.ValidInternalIPASymbol)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(changes) == 0 {
+		return trans, changes, nil
+	}
+	return strings.Join(res, ss.PhonemeDelimiter.IPA.String), changes, nil
+}
+
+func normalize(splitted []string, aliases map[string]Alias, valid func(string) bool) ([]string, []Normalization, error) {
+	var res = make([]string, 0, len(splitted))
+	var changes = make([]Normalization, 0)
+	var unknown []string
+	for i, s := range splitted {
+		if a, ok := aliases[s]; ok {
+			changes = append(changes, Normalization{Index: i, From: s, To: a.Canonical, Deprecated: a.Deprecated})
+			res = append(res, a.Canonical)
+		} else {
+			if !valid(s) {
+				unknown = append(unknown, s)
+			}
+			res = append(res, s)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, nil, UnknownInputSymbol(unknown)
+	}
+	return res, changes, nil
+}
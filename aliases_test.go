@@ -0,0 +1,149 @@
+package symbolset
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var aliasTestInput = strings.Join([]string{
+	header,
+	"sil	i:	iː	U+0069U+02D0	Syllabic",
+	"god	g	ɡ	U+0261	NonSyllabic",
+	"sol	s	s	U+0073	NonSyllabic",
+	"phoneme delimiter	 			PhonemeDelimiter",
+	"ALIAS	IPA	g	ɡ",
+	"DEPRECATED	SYMBOLS	s'	s",
+	"TEST	REJECT	SYMBOLS	s' i:",
+}, "\n") + "\n"
+
+func Test_Aliases(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(aliasTestInput))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if len(ss.Aliases) != 2 {
+		t.Errorf("expected 2 aliases, got %d", len(ss.Aliases))
+	}
+	if !ss.IsAlias("s'") {
+		t.Errorf("expected /s'/ to be an alias")
+	}
+	if ss.ValidSymbol("s'") {
+		t.Errorf("expected /s'/ not to be a valid symbol")
+	}
+
+	splitted, err := ss.SplitTranscription("s' i: g")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if strings.Join(splitted, "|") != "s'|i:|g" {
+		t.Errorf(fsExp, "s'|i:|g", strings.Join(splitted, "|"))
+	}
+
+	ipa, err := ss.ConvertToInternalIPA("s' i: g")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if ipa != "siːɡ" {
+		t.Errorf(fsExp, "siːɡ", ipa)
+	}
+
+	sym, err := ss.ConvertFromInternalIPA("giː")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if sym != "g i:" {
+		t.Errorf(fsExp, "g i:", sym)
+	}
+}
+
+func Test_Normalize(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(aliasTestInput))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+
+	result, changes, err := ss.Normalize("s' i: s'")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if result != "s i: s" {
+		t.Errorf(fsExp, "s i: s", result)
+	}
+	if len(changes) != 2 {
+		t.Errorf("expected 2 changes, got %v", changes)
+	} else if changes[1] != (Normalization{Index: 2, From: "s'", To: "s", Deprecated: true}) {
+		t.Errorf(fsExp, Normalization{Index: 2, From: "s'", To: "s", Deprecated: true}, changes[1])
+	}
+
+	result, changes, err = ss.Normalize("s i:")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if result != "s i:" || len(changes) != 0 {
+		t.Errorf("expected no changes, got /%s/ %v", result, changes)
+	}
+
+	result, changes, err = ss.NormalizeInternalIPA("giː")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if result != "ɡiː" {
+		t.Errorf(fsExp, "ɡiː", result)
+	}
+	if len(changes) != 1 || changes[0].Deprecated {
+		t.Errorf("expected 1 non-deprecated change, got %v", changes)
+	}
+
+	if _, _, err = ss.Normalize("s x"); err == nil {
+		t.Errorf("expected error for unknown symbol")
+	}
+}
+
+func Test_Aliases_Invalid(t *testing.T) {
+	symbols := []Symbol{
+		{String: "s", Cat: NonSyllabic, IPA: IPASymbol{String: "s", Unicode: "U+0073"}},
+		{String: "z", Cat: NonSyllabic, IPA: IPASymbol{String: "z", Unicode: "U+007A"}},
+		{String: " ", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	for _, aliases := range [][]Alias{
+		{{Alias: "s'", Canonical: "x"}},
+		{{Alias: "z", Canonical: "s"}},
+		{{Alias: "s'", Canonical: "s"}, {Alias: "s'", Canonical: "z"}},
+		{{Alias: "", Canonical: "s"}},
+	} {
+		if _, err := NewSymbolSetWithOptions("test", symbols, SymbolSetOptions{Aliases: aliases}); err == nil {
+			t.Errorf("expected error for aliases %v", aliases)
+		}
+	}
+}
+
+func Test_Aliases_WriteSym(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(aliasTestInput))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := ss.WriteSym(&buf); err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if buf.String() != aliasTestInput {
+		t.Errorf(fsExp, aliasTestInput, buf.String())
+	}
+
+	buf.Reset()
+	if err := ss.WriteJSON(&buf); err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	ss2, err := ReadSymbolSetJSON(&buf)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if len(ss2.Aliases) != 2 || ss2.Aliases[1] != ss.Aliases[1] {
+		t.Errorf(fsExp, ss.Aliases, ss2.Aliases)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Package builtin contains language independent reference symbol sets for X-SAMPA, Kirshenbaum (ASCII-IPA) and ARPAbet, embedded in the binary.
+
+The symbol sets are defined in .sym files in this folder (see the parent package 'symbolset' for the file format), and can be used to map any language's symbol set to or from these standards without extra files. To register the symbol sets in a mapper.Service, use Service.LoadBuiltins.
+*/
+package builtin
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stts-se/symbolset"
+)
+
+// Names of the built-in symbol sets
+const (
+	XSAMPA      = "x-sampa"
+	Kirshenbaum = "kirshenbaum"
+	ARPAbet     = "arpabet"
+)
+
+//go:embed *.sym
+var files embed.FS
+
+// Names lists the names of all built-in symbol sets, sorted alphabetically
+func Names() []string {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		// can't happen: the embedded folder always exists
+		panic(err)
+	}
+	var res []string
+	for _, e := range entries {
+		res = append(res, strings.TrimSuffix(e.Name(), symbolset.SymbolSetSuffix))
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Load loads the named built-in symbol set
+func Load(name string) (symbolset.SymbolSet, error) {
+	ss, err := symbolset.LoadSymbolSetFS(files, name+symbolset.SymbolSetSuffix)
+	if err != nil {
+		return symbolset.SymbolSet{}, fmt.Errorf("couldn't load built-in symbol set %s : %w", name, err)
+	}
+	return ss, nil
+}
+
+// LoadAll loads all built-in symbol sets, mapped by name
+func LoadAll() (map[string]symbolset.SymbolSet, error) {
+	return symbolset.LoadSymbolSetsFromFS(files, ".")
+}
@@ -0,0 +1,79 @@
+package builtin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stts-se/symbolset"
+)
+
+func Test_Names(t *testing.T) {
+	exp := []string{ARPAbet, Kirshenbaum, XSAMPA}
+	if got := Names(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func Test_Load(t *testing.T) {
+	for _, name := range Names() {
+		ss, err := Load(name)
+		if err != nil {
+			t.Errorf("Load() didn't expect error here : %v", err)
+			continue
+		}
+		if ss.Name != name {
+			t.Errorf("expected name %s, got %s", name, ss.Name)
+		}
+		for _, issue := range symbolset.Lint(ss) {
+			if issue.Severity > symbolset.SeverityInfo {
+				t.Errorf("%s:%d: unexpected lint issue: %v", name, issue.Line, issue)
+			}
+		}
+	}
+	if _, err := Load("sampa"); err == nil {
+		t.Errorf("expected error for unknown built-in symbol set")
+	}
+}
+
+func Test_LoadAll(t *testing.T) {
+	symbolSets, err := LoadAll()
+	if err != nil {
+		t.Fatalf("LoadAll() didn't expect error here : %v", err)
+	}
+	if len(symbolSets) != len(Names()) {
+		t.Errorf("expected %d symbol sets, got %d", len(Names()), len(symbolSets))
+	}
+}
+
+func Test_IPARoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name, input, ipa string
+	}{
+		{XSAMPA, "\"s\\i.dZa", "ˈɕi.d⁀ʒa"},
+		{XSAMPA, "b_<a_H!a", "ɓa\u0301ꜜa"},
+		{Kirshenbaum, "'tS&n<h>", "ˈt⁀ʃænʰ"},
+		{Kirshenbaum, "n.a\"l^", "ɳɐʎ"},
+		{ARPAbet, "JH AH1 NG . K EL", "ˈd⁀ʒʌŋ.kl̩"},
+	} {
+		ss, err := Load(test.name)
+		if err != nil {
+			t.Fatalf("Load() didn't expect error here : %v", err)
+		}
+		ipa, err := ss.ConvertToInternalIPA(test.input)
+		if err != nil {
+			t.Errorf("ConvertToInternalIPA() didn't expect error here : %v", err)
+			continue
+		}
+		if ipa != test.ipa {
+			t.Errorf("%s: expected /%s/, got /%s/", test.name, test.ipa, ipa)
+		}
+		back, err := ss.ConvertFromInternalIPA(ipa)
+		if err != nil {
+			t.Errorf("ConvertFromInternalIPA() didn't expect error here : %v", err)
+			continue
+		}
+		if back != test.input {
+			t.Errorf("%s: expected /%s/, got /%s/", test.name, test.input, back)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Command sscompound checks that the compound delimiters in lexicon transcriptions are aligned with the compound boundaries in the decompounded orthography (e.g., fot+boll).
+//
+// Usage:
+//
+//	sscompound [flags] <symbol set file> <lexicon files>
+//
+// The lexicon files are tab separated, with the decompounded orthography and the transcription in the fields specified by -decomp and -trans.
+// Issues are printed as <file>:<line>: <decompounded>: <transcription>: <diagnostic>. The exit status is 1 if any issue is found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stts-se/symbolset"
+)
+
+func checkFile(ss symbolset.SymbolSet, fName string, delim string, decompField int, transField int) (int, error) {
+	fh, err := os.Open(filepath.Clean(fName))
+	if err != nil {
+		return 0, err
+	}
+	/* #nosec G307 */
+	defer fh.Close()
+	res, err := ss.CheckCompoundAlignmentLexicon(fh, delim, decompField, transField)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", fName, err)
+	}
+	n := 0
+	for _, entry := range res {
+		for _, d := range entry.Diagnostics {
+			fmt.Printf("%s:%d: %s: %s: %s\n", fName, entry.Line, entry.Decompounded, entry.Transcription, d)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func main() {
+	decomp := flag.Int("decomp", 1, "`field` number of the decompounded orthography (starting at 1)")
+	trans := flag.Int("trans", 2, "`field` number of the transcription (starting at 1)")
+	delim := flag.String("delim", symbolset.DefaultDecompoundDelimiter, "compound boundary marker in the decompounded orthography")
+
+	var printUsage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sscompound [flags] <symbol set file> <lexicon files>\n")
+		flag.PrintDefaults()
+	}
+	flag.Usage = func() {
+		printUsage()
+		os.Exit(0)
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+	if *decomp < 1 || *trans < 1 {
+		fmt.Fprintf(os.Stderr, "field numbers start at 1\n")
+		os.Exit(1)
+	}
+	if *delim == "" {
+		fmt.Fprintf(os.Stderr, "empty compound boundary marker\n")
+		os.Exit(1)
+	}
+
+	ss, err := symbolset.LoadSymbolSet(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, f := range flag.Args()[1:] {
+		n, err := checkFile(ss, f, *delim, *decomp-1, *trans-1)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			failed = true
+			continue
+		}
+		if n > 0 {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,117 @@
+// Command ssfmt formats symbol set files (.sym) in canonical form: the IPA UNICODE column is recomputed, stray white space is removed, and (optionally) the symbols are grouped by category.
+//
+// Usage:
+//
+//	ssfmt [flags] <symbol set files or folders>
+//
+// By default, the formatted files are printed to stdout. With -w, the files are rewritten in place. With -check, the files that are not in canonical form are listed, and the exit status is 1 if there are any.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stts-se/symbolset"
+)
+
+func symFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), symbolset.SymbolSetSuffix) {
+			res = append(res, filepath.Join(path, e.Name()))
+		}
+	}
+	return res, nil
+}
+
+// firstDiff returns the first line number where the two inputs differ
+func firstDiff(a, b []byte) int {
+	la, lb := strings.Split(string(a), "\n"), strings.Split(string(b), "\n")
+	for i := 0; i < len(la) && i < len(lb); i++ {
+		if la[i] != lb[i] {
+			return i + 1
+		}
+	}
+	return min(len(la), len(lb)) + 1
+}
+
+func main() {
+	write := flag.Bool("w", false, "write the result to the source file instead of stdout")
+	check := flag.Bool("check", false, "list the files that are not in canonical form, and exit with an error if there are any")
+	group := flag.Bool("group", false, "group symbols by category")
+
+	var printUsage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: ssfmt [flags] <symbol set files or folders>\n")
+		flag.PrintDefaults()
+	}
+	flag.Usage = func() {
+		printUsage()
+		os.Exit(0)
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+	opts := symbolset.FormatOptions{GroupByCategory: *group}
+
+	failed := false
+	for _, arg := range flag.Args() {
+		files, err := symFiles(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			failed = true
+			continue
+		}
+		for _, f := range files {
+			input, err := os.ReadFile(filepath.Clean(f))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				failed = true
+				continue
+			}
+			output, err := symbolset.FormatSym(bytes.NewReader(input), opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", f, err)
+				failed = true
+				continue
+			}
+			switch {
+			case *check:
+				if !bytes.Equal(input, output) {
+					fmt.Printf("%s:%d: not in canonical form\n", f, firstDiff(input, output))
+					failed = true
+				}
+			case *write:
+				if !bytes.Equal(input, output) {
+					/* #nosec G306 */
+					if err := os.WriteFile(f, output, 0644); err != nil {
+						fmt.Fprintf(os.Stderr, "%v\n", err)
+						failed = true
+					}
+				}
+			default:
+				os.Stdout.Write(output)
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,128 @@
+// Command ssgen generates a symbol set file (.sym) from a list of IPA phonemes. Categories are inferred from the IPA, descriptions are taken from the IPA character database, and the IPA UNICODE column is computed.
+//
+// Usage:
+//
+//	ssgen [flags] <IPA phonemes, or - to read phonemes from stdin>
+//
+// Phonemes read from stdin are separated by white space (one or more per line), and lines starting with # are ignored.
+// The symbols are transliterated using the symbol set specified by -scheme (a built-in symbol set, or a .sym file). If no scheme is specified, the IPA symbols are used as symbols.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/stts-se/symbolset"
+	"github.com/stts-se/symbolset/builtin"
+	"golang.org/x/text/language"
+)
+
+func loadScheme(scheme string) (symbolset.SymbolSet, error) {
+	if slices.Contains(builtin.Names(), scheme) {
+		return builtin.Load(scheme)
+	}
+	return symbolset.LoadSymbolSet(scheme)
+}
+
+func readInventory(args []string) ([]string, error) {
+	if len(args) != 1 || args[0] != "-" {
+		return args, nil
+	}
+	var res []string
+	s := bufio.NewScanner(os.Stdin)
+	for s.Scan() {
+		l := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(l, "#") {
+			continue
+		}
+		res = append(res, strings.Fields(l)...)
+	}
+	return res, s.Err()
+}
+
+func main() {
+	scheme := flag.String("scheme", "", fmt.Sprintf("transliteration `scheme`: a built-in symbol set (%s), or a .sym file", strings.Join(builtin.Names(), ", ")))
+	name := flag.String("name", "", "symbol set `name` (default: derived from the output file name)")
+	lang := flag.String("language", "", "symbol set language (BCP 47 language tag)")
+	output := flag.String("o", "", "output `file` (default: stdout)")
+	phnDelim := flag.String("phoneme-delimiter", " ", "phoneme delimiter")
+	syllDelim := flag.String("syllable-delimiter", ".", "syllable delimiter (empty for none)")
+	morphDelim := flag.String("morpheme-delimiter", "", "morpheme delimiter (empty for none)")
+	compDelim := flag.String("compound-delimiter", "", "compound delimiter (empty for none)")
+
+	var printUsage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: ssgen [flags] <IPA phonemes, or - to read phonemes from stdin>\n")
+		flag.PrintDefaults()
+	}
+	flag.Usage = func() {
+		printUsage()
+		os.Exit(0)
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+	inventory, err := readInventory(flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't read phonemes : %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := symbolset.SkeletonOptions{
+		PhonemeDelimiter:  *phnDelim,
+		SyllableDelimiter: *syllDelim,
+		MorphemeDelimiter: *morphDelim,
+		CompoundDelimiter: *compDelim,
+	}
+	if *scheme != "" {
+		ss, err := loadScheme(*scheme)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't load scheme : %v\n", err)
+			os.Exit(1)
+		}
+		opts.Scheme = &ss
+	}
+
+	ssName := *name
+	if ssName == "" && *output != "" {
+		ssName = strings.TrimSuffix(filepath.Base(*output), filepath.Ext(*output))
+	}
+	if ssName == "" {
+		ssName = "skeleton"
+	}
+	ss, err := symbolset.NewSymbolSetSkeleton(ssName, inventory, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	if *lang != "" {
+		tag, err := language.Parse(*lang)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -language : %v\n", err)
+			os.Exit(1)
+		}
+		ss.Language = tag.String()
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		w, err = os.Create(filepath.Clean(*output))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		/* #nosec G307 */
+		defer w.Close()
+	}
+	if err := ss.WriteSym(w); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,98 @@
+// Command sslint checks symbol set files (.sym) for possible problems, such as duplicate symbols, unknown IPA characters and categories that contradict the IPA.
+//
+// Usage:
+//
+//	sslint [flags] <symbol set files or folders>
+//
+// Issues are printed as <file>:<line>: <severity>: <code>: <message>. The exit status is 1 if any issue has the severity specified by -fail (or higher).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stts-se/symbolset"
+)
+
+func symFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), symbolset.SymbolSetSuffix) {
+			res = append(res, filepath.Join(path, e.Name()))
+		}
+	}
+	return res, nil
+}
+
+func main() {
+	level := flag.String("level", "info", "minimum `severity` to print (info, warning or error)")
+	fail := flag.String("fail", "error", "exit with an error if any issue has this `severity` or higher (info, warning or error)")
+
+	var printUsage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sslint [flags] <symbol set files or folders>\n")
+		flag.PrintDefaults()
+	}
+	flag.Usage = func() {
+		printUsage()
+		os.Exit(0)
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+	minLevel, err := symbolset.SeverityFromString(*level)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -level : %v\n", err)
+		os.Exit(1)
+	}
+	failOn, err := symbolset.SeverityFromString(*fail)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -fail : %v\n", err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, arg := range flag.Args() {
+		files, err := symFiles(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			failed = true
+			continue
+		}
+		for _, f := range files {
+			ss, err := symbolset.LoadSymbolSet(f)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				failed = true
+				continue
+			}
+			for _, issue := range symbolset.Lint(ss) {
+				if issue.Severity >= failOn {
+					failed = true
+				}
+				if issue.Severity >= minLevel {
+					fmt.Printf("%s:%d: %s: %s: %s\n", f, issue.Line, issue.Severity, issue.Code, issue.Message)
+				}
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,279 @@
+package symbolset
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// alignment of compound delimiters with the decompounded orthography
+
+// DefaultDecompoundDelimiter is the compound boundary marker commonly used in decompounded orthography (e.g., fot+boll)
+const DefaultDecompoundDelimiter = "+"
+
+// vowelLetters are the letters that are aligned with syllabic phonemes at a lower cost than other letters (after removing diacritics)
+const vowelLetters = "aeiouyæøœ"
+
+// alignment costs for letters and phonemes
+const (
+	alignMatch = 0
+	alignVowel = 1 // a vowel letter aligned with a different syllabic phoneme
+	alignSubst = 2
+	alignIndel = 2
+)
+
+// baseLetter returns the first letter of the string, lower cased and without diacritics, or 0 if there is no letter
+func baseLetter(s string) rune {
+	for _, r := range norm.NFD.String(s) {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+	}
+	return 0
+}
+
+// alignCost is the cost of aligning a letter with a phoneme
+func alignCost(letter rune, phoneme posToken, phonemeLetter rune) int {
+	switch {
+	case letter == phonemeLetter:
+		return alignMatch
+	case strings.ContainsRune(vowelLetters, letter) && phoneme.cat == Syllabic:
+		return alignVowel
+	default:
+		return alignSubst
+	}
+}
+
+// alignedPositions aligns the letters with the phonemes (a weighted edit distance), and returns, for each letter position k (0 <= k <= len(letters)), the phoneme positions j such that some optimal alignment maps the first k letters to the first j phonemes
+func (ss SymbolSet) alignedPositions(letters []rune, phonemes []posToken) [][]int {
+	phonemeLetters := make([]rune, len(phonemes))
+	for j, p := range phonemes {
+		s := p.s
+		if p.known {
+			if sym, err := ss.Get(p.s); err == nil && len(sym.IPA.String) > 0 {
+				s = sym.IPA.String
+			}
+		}
+		phonemeLetters[j] = baseLetter(s)
+	}
+	n, m := len(letters), len(phonemes)
+	newTable := func() [][]int {
+		t := make([][]int, n+1)
+		for i := range t {
+			t[i] = make([]int, m+1)
+		}
+		return t
+	}
+
+	// forward: cost of aligning the first i letters with the first j phonemes
+	fw := newTable()
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= m; j++ {
+			switch {
+			case i == 0:
+				fw[i][j] = j * alignIndel
+			case j == 0:
+				fw[i][j] = i * alignIndel
+			default:
+				fw[i][j] = min(fw[i-1][j-1]+alignCost(letters[i-1], phonemes[j-1], phonemeLetters[j-1]), fw[i-1][j]+alignIndel, fw[i][j-1]+alignIndel)
+			}
+		}
+	}
+	// backward: cost of aligning the letters from i with the phonemes from j
+	bw := newTable()
+	for i := n; i >= 0; i-- {
+		for j := m; j >= 0; j-- {
+			switch {
+			case i == n:
+				bw[i][j] = (m - j) * alignIndel
+			case j == m:
+				bw[i][j] = (n - i) * alignIndel
+			default:
+				bw[i][j] = min(bw[i+1][j+1]+alignCost(letters[i], phonemes[j], phonemeLetters[j]), bw[i+1][j]+alignIndel, bw[i][j+1]+alignIndel)
+			}
+		}
+	}
+
+	best := fw[n][m]
+	res := make([][]int, n+1)
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= m; j++ {
+			if fw[i][j]+bw[i][j] == best {
+				res[i] = append(res[i], j)
+			}
+		}
+	}
+	return res
+}
+
+// distance returns the distance from j to the closest of the positions
+func distance(j int, positions []int) int {
+	res := -1
+	for _, p := range positions {
+		d := max(j-p, p-j)
+		if res < 0 || d < res {
+			res = d
+		}
+	}
+	return res
+}
+
+// orthBoundary is a compound boundary in the decompounded orthography
+type orthBoundary struct {
+	// letters is the number of letters preceding the boundary
+	letters int
+	// before and after are the orthographic parts surrounding the boundary
+	before, after string
+}
+
+// transBoundary is a compound delimiter in the transcription
+type transBoundary struct {
+	token posToken
+	// phonemes is the number of phonemes preceding the delimiter
+	phonemes int
+}
+
+// CheckCompoundAlignment checks that the compound delimiters in the transcription are aligned with the compound boundaries in the decompounded orthography (marked using delimiter, e.g., fot+boll, using DefaultDecompoundDelimiter).
+// The letters of the orthography are aligned with the phonemes of the transcription, and each compound boundary is matched with a compound delimiter.
+// Missing compound delimiters are reported at the phoneme where the delimiter is expected, and extra or misaligned delimiters at the delimiter itself.
+// The diagnostics returned have the positions in the transcription, sorted by position. Morpheme delimiters are not checked, since they need not align with morpheme boundaries in the orthography.
+// If the symbol set has no compound delimiter, nothing is checked.
+func (ss SymbolSet) CheckCompoundAlignment(decompounded string, delimiter string, trans string) []Diagnostic {
+	if len(filterSymbolsByCat(ss.Symbols, []SymbolCat{CompoundDelimiter})) == 0 {
+		return nil
+	}
+
+	var letters []rune
+	var orth []orthBoundary
+	parts := []string{decompounded}
+	if delimiter != "" {
+		parts = strings.Split(decompounded, delimiter)
+	}
+	for i, part := range parts {
+		if i > 0 && len(letters) > 0 && strings.TrimSpace(part) != "" {
+			orth = append(orth, orthBoundary{letters: len(letters), before: strings.TrimSpace(parts[i-1]), after: strings.TrimSpace(part)})
+		}
+		for _, r := range part {
+			if l := baseLetter(string(r)); l != 0 {
+				letters = append(letters, l)
+			}
+		}
+	}
+
+	var phonemes []posToken
+	var delims []transBoundary
+	for _, t := range ss.tokenize(trans, false) {
+		if t.known && t.cat == CompoundDelimiter {
+			delims = append(delims, transBoundary{token: t, phonemes: len(phonemes)})
+		} else if !t.known || t.cat == Syllabic || t.cat == NonSyllabic {
+			phonemes = append(phonemes, t)
+		}
+	}
+	if len(orth) == 0 && len(delims) == 0 {
+		return nil
+	}
+
+	positions := ss.alignedPositions(letters, phonemes)
+
+	// match the orthographic boundaries with the compound delimiters, in order, minimizing the total distance
+	// skipping a boundary costs more than any match, so that as many as possible are matched
+	skip := len(phonemes) + 1
+	n, m := len(orth), len(delims)
+	cost := make([][]int, n+1)
+	for i := range cost {
+		cost[i] = make([]int, m+1)
+	}
+	for i := n; i >= 0; i-- {
+		for j := m; j >= 0; j-- {
+			switch {
+			case i == n:
+				cost[i][j] = (m - j) * skip
+			case j == m:
+				cost[i][j] = (n - i) * skip
+			default:
+				cost[i][j] = min(cost[i+1][j+1]+distance(delims[j].phonemes, positions[orth[i].letters]), cost[i+1][j]+skip, cost[i][j+1]+skip)
+			}
+		}
+	}
+
+	var res []Diagnostic
+	expectedAt := func(b orthBoundary) (posToken, string) {
+		j := positions[b.letters][0]
+		if j < len(phonemes) {
+			return phonemes[j], fmt.Sprintf("expected before '%s'", phonemes[j].s)
+		}
+		if len(phonemes) > 0 {
+			return phonemes[len(phonemes)-1], "expected at the end"
+		}
+		return posToken{}, "expected at the end"
+	}
+	for i, j := 0, 0; i < n || j < m; {
+		switch {
+		case i < n && j < m && cost[i][j] == cost[i+1][j+1]+distance(delims[j].phonemes, positions[orth[i].letters]):
+			if distance(delims[j].phonemes, positions[orth[i].letters]) > 0 {
+				_, expected := expectedAt(orth[i])
+				res = append(res, diagnosticAt(trans, delims[j].token, MisalignedCompoundDelimiterDiagnostic(delims[j].token.s, orth[i].before, orth[i].after, expected)))
+			}
+			i++
+			j++
+		case i < n && cost[i][j] == cost[i+1][j]+skip:
+			t, expected := expectedAt(orth[i])
+			res = append(res, diagnosticAt(trans, t, MissingCompoundDelimiterDiagnostic(orth[i].before, orth[i].after, expected)))
+			i++
+		default:
+			res = append(res, diagnosticAt(trans, delims[j].token, ExtraCompoundDelimiterDiagnostic(delims[j].token.s, decompounded)))
+			j++
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ByteOffset < res[j].ByteOffset })
+	return res
+}
+
+// LexiconDiagnostics are the diagnostics for one lexicon entry, as returned by CheckCompoundAlignmentLexicon
+type LexiconDiagnostics struct {
+	// Line is the line number in the lexicon file (starting at 1)
+	Line int
+
+	Decompounded  string
+	Transcription string
+	Diagnostics   []Diagnostic
+}
+
+// CheckCompoundAlignmentLexicon runs CheckCompoundAlignment for each entry in a tab separated lexicon file, with the decompounded orthography (using delimiter as the compound boundary marker) and the transcription in the specified fields (starting at 0).
+// Empty lines, and lines starting with #, are skipped. Only entries with diagnostics are returned.
+func (ss SymbolSet) CheckCompoundAlignmentLexicon(r io.Reader, delimiter string, decompoundedField int, transField int) ([]LexiconDiagnostics, error) {
+	if decompoundedField < 0 || transField < 0 {
+		return nil, fmt.Errorf("invalid field index (fields start at 0) : %d, %d", decompoundedField, transField)
+	}
+	if delimiter == "" {
+		return nil, fmt.Errorf("empty decompound delimiter")
+	}
+	var res []LexiconDiagnostics
+	s := bufio.NewScanner(r)
+	n := 0
+	for s.Scan() {
+		n++
+		l := s.Text()
+		if strings.TrimSpace(l) == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		if decompoundedField >= len(fs) || transField >= len(fs) {
+			return nil, fmt.Errorf("line %d: expected at least %d fields, found %d: %s", n, max(decompoundedField, transField)+1, len(fs), l)
+		}
+		decomp, trans := strings.TrimSpace(fs[decompoundedField]), strings.TrimSpace(fs[transField])
+		if ds := ss.CheckCompoundAlignment(decomp, delimiter, trans); len(ds) > 0 {
+			res = append(res, LexiconDiagnostics{Line: n, Decompounded: decomp, Transcription: trans, Diagnostics: ds})
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading lexicon : %w", err)
+	}
+	return res, nil
+}
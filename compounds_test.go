@@ -0,0 +1,101 @@
+package symbolset
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func compoundTestSet(t *testing.T) SymbolSet {
+	b, err := os.ReadFile("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	input := strings.Replace(string(b), "#compound delimiter\t-\t\tCompoundDelimiter", "compound delimiter\t-\t\t\tCompoundDelimiter", 1)
+	ss, err := ReadSymbolSet("sv-se_ws-sampa", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	return ss
+}
+
+func Test_CheckCompoundAlignment(t *testing.T) {
+	ss := compoundTestSet(t)
+	var tests = []struct {
+		decompounded string
+		input        string
+		expect       string
+	}{
+		{"fot+boll", `"" f u: t - % b O l`, ""},
+		{"fotboll", `"" f u: t . % b O l`, ""},
+		{"fot+boll", `"" f u: - t % b O l`, "Misaligned compound delimiter:-:8:"},
+		{"fot+boll", `"" f u: t % b O l`, "Missing compound delimiter:b:12:"},
+		{"fotboll", `"" f u: t - % b O l`, "Extra compound delimiter:-:10:"},
+		{"fot+bolls+lag", `"" f u: t - b O l s - % l A: g`, ""},
+		{"fot+bolls+lag", `"" f u: t - b O l s % l A: g`, "Missing compound delimiter:l:22:"},
+		{"fot+bolls+lag", `"" f u: t b O l - s % l A: g`, "Missing compound delimiter:b:10: | Misaligned compound delimiter:-:16:"},
+
+		// the double s can be aligned with either part of the compound
+		{"glas+skål", `"" g l A: s - s k o: l`, ""},
+		{"glas+skål", `"" g l A: - s k o: l`, ""},
+		{"glas+skål", `"" g l A: s k - o: l`, "Misaligned compound delimiter:-:14:"},
+	}
+	for _, test := range tests {
+		result := diagnosticsString(ss.CheckCompoundAlignment(test.decompounded, DefaultDecompoundDelimiter, test.input))
+		if result != test.expect {
+			t.Errorf("%s /%s/: "+fsExp, test.decompounded, test.input, test.expect, result)
+		}
+	}
+
+	// no compound delimiter in the symbol set
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if diags := ss.CheckCompoundAlignment("fot+boll", DefaultDecompoundDelimiter, `"" f u: t % b O l`); len(diags) != 0 {
+		t.Errorf("didn't expect diagnostics here : %v", diags)
+	}
+}
+
+func Test_CheckCompoundAlignmentLexicon(t *testing.T) {
+	ss := compoundTestSet(t)
+	lex := strings.Join([]string{
+		"# orthography	decompounded	transcription",
+		"fotboll	fot+boll	\"\" f u: t - % b O l",
+		"",
+		"fotboll	fot+boll	\"\" f u: t % b O l",
+		"fotbollslag	fot+bolls+lag	\"\" f u: t - b O l s - % l A: g",
+		"boll	boll	\" b O - l",
+	}, "\n")
+	res, err := ss.CheckCompoundAlignmentLexicon(strings.NewReader(lex), DefaultDecompoundDelimiter, 1, 2)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var lines []string
+	for _, r := range res {
+		lines = append(lines, strings.Join([]string{r.Decompounded, diagnosticsString(r.Diagnostics)}, ":"))
+	}
+	expect := "fot+boll:Missing compound delimiter:b:12: || boll:Extra compound delimiter:-:6:"
+	if result := strings.Join(lines, " || "); result != expect {
+		t.Errorf(fsExp, expect, result)
+	}
+	if len(res) != 2 || res[0].Line != 4 || res[1].Line != 6 {
+		t.Errorf("unexpected line numbers: %v", res)
+	}
+
+	if _, err := ss.CheckCompoundAlignmentLexicon(strings.NewReader("fotboll\tfot+boll\n"), DefaultDecompoundDelimiter, 1, 2); err == nil {
+		t.Errorf("expected error for missing field")
+	}
+	if _, err := ss.CheckCompoundAlignmentLexicon(strings.NewReader(lex), DefaultDecompoundDelimiter, -1, 2); err == nil {
+		t.Errorf("expected error for negative field index")
+	}
+
+	// other delimiters than the default one
+	res, err = ss.CheckCompoundAlignmentLexicon(strings.NewReader("fot#boll	\"\" f u: t % b O l\n"), "#", 0, 1)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if len(res) != 1 || diagnosticsString(res[0].Diagnostics) != "Missing compound delimiter:b:12:" {
+		t.Errorf("unexpected result for delimiter #: %v", res)
+	}
+}
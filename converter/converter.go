@@ -14,26 +14,85 @@ type Converter struct {
 	From  symbolset.SymbolSet
 	To    symbolset.SymbolSet
 	Rules []Rule
+
+	// WordAnchors is set if the rules are applied to each word separately (ANCHORS WORD in the .cnv file), so that ^ and $ in regular expression rules match word edges.
+	// Word delimiters, pauses and intonation boundaries are converted using symbol rules only.
+	WordAnchors bool
 }
 
 // Convert : converts the input transcription string
 func (c Converter) Convert(trans string) (string, error) {
+	var res string
+	var err error
+	if c.WordAnchors {
+		res, err = c.convertWords(trans)
+	} else {
+		res, err = c.applyRules(trans, c.Rules)
+	}
+	if err != nil {
+		return "", err
+	}
+	invalid, err := c.getInvalidSymbols(res, c.To)
+	if err != nil {
+		return "", err
+	}
+	if len(invalid) > 0 {
+		return res, fmt.Errorf("invalid symbol(s) in output transcription /%s/: %v", res, invalid)
+	}
+	return res, nil
+}
+
+// applyRules applies the rules to the input transcription, in order
+func (c Converter) applyRules(trans string, rules []Rule) (string, error) {
 	var res = trans
 	var err error
-	for _, r := range c.Rules {
+	for _, r := range rules {
 		res, err = r.Convert(res, c.From)
 		if err != nil {
 			return "", err
 		}
 	}
-	invalid, err := c.getInvalidSymbols(res, c.To)
+	return res, nil
+}
+
+// convertWords splits the input transcription into words using the input symbol set, and applies the rules to each word separately.
+// The word boundary symbols are converted using the symbol rules.
+func (c Converter) convertWords(trans string) (string, error) {
+	phrase, err := c.From.SplitPhrase(trans)
 	if err != nil {
 		return "", err
 	}
-	if len(invalid) > 0 {
-		return res, fmt.Errorf("invalid symbol(s) in output transcription /%s/: %v", res, invalid)
+	var symbolRules []Rule
+	for _, r := range c.Rules {
+		if r.Type() == "SYMBOL" {
+			symbolRules = append(symbolRules, r)
+		}
 	}
-	return res, nil
+	var res []string
+	appendBoundaries := func(boundaries []string) error {
+		for _, b := range boundaries {
+			s, err := c.applyRules(b, symbolRules)
+			if err != nil {
+				return err
+			}
+			res = append(res, s)
+		}
+		return nil
+	}
+	for i, word := range phrase.Words {
+		if err := appendBoundaries(phrase.Boundaries[i]); err != nil {
+			return "", err
+		}
+		s, err := c.applyRules(strings.Join(word, c.From.PhonemeDelimiter.String), c.Rules)
+		if err != nil {
+			return "", err
+		}
+		res = append(res, s)
+	}
+	if err := appendBoundaries(phrase.Boundaries[len(phrase.Words)]); err != nil {
+		return "", err
+	}
+	return strings.Join(res, c.From.PhonemeDelimiter.String), nil
 }
 
 type test struct {
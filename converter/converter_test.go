@@ -3,6 +3,7 @@ package converter
 import (
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stts-se/symbolset"
 )
@@ -28,3 +29,184 @@ func TestLoadFromDir(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"symbolsets/aa_sampa.sym": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY",
+			"a	a	a	U+0061	Syllabic",
+			"t	t	t	U+0074	NonSyllabic",
+			"th	T	θ	U+03B8	NonSyllabic",
+			"phoneme delimiter	 			PhonemeDelimiter",
+		}, "\n"))},
+		"symbolsets/bb_sampa.sym": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY",
+			"a	a	a	U+0061	Syllabic",
+			"t	t	t	U+0074	NonSyllabic",
+			"phoneme delimiter	 			PhonemeDelimiter",
+		}, "\n"))},
+		"converters/aa2bb.cnv": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"FROM	aa_sampa",
+			"TO	bb_sampa",
+			"SYMBOL	T	t",
+			"TEST	T a t	t a t",
+		}, "\n"))},
+	}
+	sSets, err := symbolset.LoadSymbolSetsFromFS(fsys, "symbolsets")
+	if err != nil {
+		t.Errorf("LoadSymbolSetsFromFS() didn't expect error here : %v", err)
+		return
+	}
+	convs, testRes, err := LoadFromFS(sSets, fsys, "converters")
+	if err != nil {
+		t.Errorf("LoadFromFS() didn't expect error here : %v", err)
+		return
+	}
+	if res, ok := testRes["aa2bb"]; !ok || !res.OK {
+		t.Errorf("Expected converter tests to pass for aa2bb, got %v", testRes)
+	}
+	conv, ok := convs["aa2bb"]
+	if !ok {
+		t.Errorf("Expected converter aa2bb, got %v", convs)
+		return
+	}
+	result, err := conv.Convert("T a")
+	if err != nil {
+		t.Errorf("Convert() didn't expect error here : %v", err)
+		return
+	}
+	if result != "t a" {
+		t.Errorf("Expected /%s/, got /%s/", "t a", result)
+	}
+
+	conv, _, err = Load(sSets, "reader", strings.NewReader("FROM	aa_sampa\nTO	bb_sampa\nSYMBOL	T	t\n"))
+	if err != nil {
+		t.Errorf("Load() didn't expect error here : %v", err)
+		return
+	}
+	if conv.Name != "reader" {
+		t.Errorf("Expected name %s, got %s", "reader", conv.Name)
+	}
+}
+
+func TestConvertToneAndPauses(t *testing.T) {
+	fsys := fstest.MapFS{
+		"symbolsets/aa_sampa.sym": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY",
+			"a	a	a	U+0061	Syllabic",
+			"t	t	t	U+0074	NonSyllabic",
+			"high tone	_1	˥	U+02E5	Tone",
+			"long	:	ː	U+02D0	Length",
+			"pause	sil	(.)	U+0028U+002EU+0029	Pause",
+			"minor group	|	|	U+007C	IntonationBoundary",
+			"phoneme delimiter	 			PhonemeDelimiter",
+		}, "\n"))},
+		"symbolsets/bb_sampa.sym": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY",
+			"a	a	a	U+0061	Syllabic",
+			"t	t	t	U+0074	NonSyllabic",
+			"high tone	1	˥	U+02E5	Tone",
+			"long	:	ː	U+02D0	Length",
+			"pause	_	(.)	U+0028U+002EU+0029	Pause",
+			"minor group	|	|	U+007C	IntonationBoundary",
+			"phoneme delimiter	 			PhonemeDelimiter",
+		}, "\n"))},
+		// tone and pause symbols missing in the target need a rule, just like phonemes
+		"converters/aa2bb.cnv": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"FROM	aa_sampa",
+			"TO	bb_sampa",
+			"SYMBOL	_1	1",
+			"SYMBOL	sil	_",
+			"TEST	t a : _1 sil t a | t a	t a : 1 _ t a | t a",
+		}, "\n"))},
+		"converters/aa2bb_FAIL.cnv": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"FROM	aa_sampa",
+			"TO	bb_sampa",
+			"SYMBOL	_1	1",
+		}, "\n"))},
+	}
+	sSets, err := symbolset.LoadSymbolSetsFromFS(fsys, "symbolsets")
+	if err != nil {
+		t.Errorf("LoadSymbolSetsFromFS() didn't expect error here : %v", err)
+		return
+	}
+	_, testRes, err := LoadFromFS(sSets, fsys, "converters")
+	if err != nil {
+		t.Errorf("LoadFromFS() didn't expect error here : %v", err)
+		return
+	}
+	if res := testRes["aa2bb"]; !res.OK {
+		t.Errorf("Expected converter tests to pass for aa2bb, got %v", res.Errors)
+	}
+	res := testRes["aa2bb_FAIL"]
+	if res.OK || !strings.Contains(strings.Join(res.Errors, "\n"), "Symbol rule needed for input phoneme /sil/") {
+		t.Errorf("Expected missing rule for /sil/ in aa2bb_FAIL, got %v", res.Errors)
+	}
+}
+
+func TestConvertWordAnchors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"symbolsets/aa_sampa.sym": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY",
+			"a	a	a	U+0061	Syllabic",
+			"t	t	t	U+0074	NonSyllabic",
+			"th	T	θ	U+03B8	NonSyllabic",
+			"word delimiter	#	‿	U+203F	WordDelimiter",
+			"pause	sil	(.)	U+0028U+002EU+0029	Pause",
+			"phoneme delimiter	 			PhonemeDelimiter",
+		}, "\n"))},
+		"symbolsets/bb_sampa.sym": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY",
+			"a	a	a	U+0061	Syllabic",
+			"t	t	t	U+0074	NonSyllabic",
+			"s	s	s	U+0073	NonSyllabic",
+			"word delimiter	#	‿	U+203F	WordDelimiter",
+			"pause	_	(.)	U+0028U+002EU+0029	Pause",
+			"phoneme delimiter	 			PhonemeDelimiter",
+		}, "\n"))},
+		// word initial T is converted to t, other T to s
+		"converters/aa2bb_word.cnv": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"FROM	aa_sampa",
+			"TO	bb_sampa",
+			"ANCHORS	WORD",
+			"RE	^T	t",
+			"RE	T$	s",
+			"SYMBOL	T	s",
+			"SYMBOL	sil	_",
+			"TEST	T a T # T a sil T a	t a s # t a _ t a",
+		}, "\n"))},
+		"converters/aa2bb_transcription.cnv": &fstest.MapFile{Data: []byte(strings.Join([]string{
+			"FROM	aa_sampa",
+			"TO	bb_sampa",
+			"ANCHORS	TRANSCRIPTION",
+			"RE	^T	t",
+			"RE	T$	s",
+			"SYMBOL	T	s",
+			"SYMBOL	sil	_",
+			"TEST	T a T # T a sil T a	t a s # s a _ s a",
+		}, "\n"))},
+	}
+	sSets, err := symbolset.LoadSymbolSetsFromFS(fsys, "symbolsets")
+	if err != nil {
+		t.Errorf("LoadSymbolSetsFromFS() didn't expect error here : %v", err)
+		return
+	}
+	convs, testRes, err := LoadFromFS(sSets, fsys, "converters")
+	if err != nil {
+		t.Errorf("LoadFromFS() didn't expect error here : %v", err)
+		return
+	}
+	for name, res := range testRes {
+		if !res.OK {
+			t.Errorf("Expected converter tests to pass for %s, got %v", name, res.Errors)
+		}
+	}
+	if !convs["aa2bb_word"].WordAnchors || convs["aa2bb_transcription"].WordAnchors {
+		t.Errorf("Expected word anchors for aa2bb_word only")
+	}
+
+	_, _, err = Load(sSets, "invalid", strings.NewReader("FROM	aa_sampa\nTO	bb_sampa\nANCHORS	SENTENCE\n"))
+	if err == nil {
+		t.Errorf("Load() expected error for invalid anchors")
+	}
+}
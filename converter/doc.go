@@ -38,6 +38,15 @@ Sample file for US English Sampa to Swedish Sampa (defined in test_data/enusampa
 	TEST	T i s	t I s
 	TEST	D i s	d I s
 
+By default, the rules are applied to the whole input transcription, so ^ and $ in regular expression rules match the start and end of the transcription.
+For phrase transcriptions, add the line
+
+	ANCHORS	WORD
+
+to apply the rules to each word separately (words are separated by the input symbol set's WordDelimiter, Pause and IntonationBoundary symbols). Then ^ and $ match word edges, and no rule can match across a word boundary. The boundary symbols themselves are converted using SYMBOL rules only.
+
+When a converter is loaded, every symbol in the input symbol set is checked. This includes stress, tone, length, diacritic, pause and intonation boundary symbols. A symbol that is missing in the output symbol set needs a SYMBOL rule.
+
 For real world examples (used for unit tests), see the test_data folder: https://github.com/stts-se/symbolset/tree/master/test_data
 
 To test a single .cnv file from the command line, use symbolset/converter/cmd/converter.
@@ -3,8 +3,10 @@ package converter
 import (
 	"bufio"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"regexp"
@@ -46,6 +48,21 @@ func isTo(s string) bool {
 	return strings.HasPrefix(s, "TO\t")
 }
 
+func isAnchors(s string) bool {
+	return strings.HasPrefix(s, "ANCHORS\t")
+}
+
+var anchorsRe = regexp.MustCompile("^ANCHORS\t(WORD|TRANSCRIPTION)$")
+
+// parseAnchors returns true if ^ and $ in regexp rules should match word edges (ANCHORS WORD), and false if they should match the edges of the whole transcription (ANCHORS TRANSCRIPTION)
+func parseAnchors(s string) (bool, error) {
+	var matchRes []string = anchorsRe.FindStringSubmatch(s)
+	if matchRes == nil {
+		return false, fmt.Errorf("invalid anchors definition (expected WORD or TRANSCRIPTION): %s", s)
+	}
+	return matchRes[1] == "WORD", nil
+}
+
 func isRegexpRule(s string) bool {
 	return strings.HasPrefix(s, "RE\t")
 }
@@ -99,19 +116,38 @@ func parseSymbolSet(s string) (string, error) {
 
 // LoadFile loads a converter file and runs the specified tests
 func LoadFile(symbolSets map[string]symbolset.SymbolSet, fName string) (Converter, TestResult, error) {
-	name := filepath.Base(fName)
-	var extension = filepath.Ext(name)
-	name = name[0 : len(name)-len(extension)]
-	var converter = Converter{Name: name}
-	var err error
 	fh, err := os.Open(filepath.Clean(fName))
 	if err != nil {
 		return Converter{}, TestResult{}, err
 	}
 	/* #nosec G307 */
 	defer fh.Close()
+	return Load(symbolSets, converterName(fName), fh)
+}
+
+// LoadFileFS loads a converter file from the specified file system (e.g., embed.FS or fstest.MapFS) and runs the specified tests
+func LoadFileFS(symbolSets map[string]symbolset.SymbolSet, fsys fs.FS, fName string) (Converter, TestResult, error) {
+	fh, err := fsys.Open(fName)
+	if err != nil {
+		return Converter{}, TestResult{}, err
+	}
+	/* #nosec G307 */
+	defer fh.Close()
+	return Load(symbolSets, converterName(fName), fh)
+}
+
+// converterName returns the converter name for a file name (the base name without extension)
+func converterName(fName string) string {
+	name := path.Base(filepath.ToSlash(fName))
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// Load reads a converter from the reader, names the converter, and runs the specified tests
+func Load(symbolSets map[string]symbolset.SymbolSet, name string, r io.Reader) (Converter, TestResult, error) {
+	var converter = Converter{Name: name}
+	var err error
 	n := 0
-	s := bufio.NewScanner(fh)
+	s := bufio.NewScanner(r)
 	var testLines []test
 	for s.Scan() {
 		if err := s.Err(); err != nil {
@@ -140,6 +176,11 @@ func LoadFile(symbolSets map[string]symbolset.SymbolSet, fName string) (Converte
 			} else {
 				return Converter{}, TestResult{}, fmt.Errorf("symbolset not defined: %s", ss)
 			}
+		} else if isAnchors(l) {
+			converter.WordAnchors, err = parseAnchors(l)
+			if err != nil {
+				return Converter{}, TestResult{}, err
+			}
 		} else if isSymbolRule(l) {
 			rule, err := parseSymbolRule(l)
 			if err != nil {
@@ -172,18 +213,23 @@ var Suffix = ".cnv"
 
 // LoadFromDir loads a converters from the specified folder (all files with .cnv extension)
 func LoadFromDir(symbolSets map[string]symbolset.SymbolSet, dirName string) (map[string]Converter, map[string]TestResult, error) {
+	return LoadFromFS(symbolSets, os.DirFS(dirName), ".")
+}
+
+// LoadFromFS loads converters from the specified folder in the file system (all files with .cnv extension)
+func LoadFromFS(symbolSets map[string]symbolset.SymbolSet, fsys fs.FS, dirName string) (map[string]Converter, map[string]TestResult, error) {
 	// list files in dir
-	fileInfos, err := ioutil.ReadDir(dirName)
+	dirEntries, err := fs.ReadDir(fsys, dirName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed reading symbol set dir : %w", err)
 	}
 	var fErrs error
 	var convs = make(map[string]Converter)
 	var res = make(map[string]TestResult)
-	for _, fi := range fileInfos {
+	for _, fi := range dirEntries {
 		var testResult = TestResult{OK: true}
-		if strings.HasSuffix(fi.Name(), Suffix) {
-			conv, testRes, err := LoadFile(symbolSets, filepath.Join(dirName, fi.Name()))
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), Suffix) {
+			conv, testRes, err := LoadFileFS(symbolSets, fsys, path.Join(dirName, fi.Name()))
 			if err != nil {
 				thisErr := fmt.Errorf("could't load converter from file %s : %w", fi.Name(), err)
 				if fErrs != nil {
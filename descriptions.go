@@ -0,0 +1,41 @@
+package symbolset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stts-se/symbolset/ipa"
+)
+
+// descriptions of IPA symbols, using the IPA character database
+
+// DescribeIPA returns a readable description of the IPA symbol string, using the IPA character database (see package ipa)
+func DescribeIPA(s string) string {
+	return ipa.Describe(s)
+}
+
+// Description returns the description of the symbol. For phonetic symbols without description, a description is generated from the IPA, using the IPA character database (see DescribeIPA).
+// The Desc field is not changed, so that the symbol set is written as it was read.
+func (s Symbol) Description() string {
+	if strings.TrimSpace(s.Desc) == "" && isPhoneticCat(s.Cat) && s.IPA.String != "" {
+		return DescribeIPA(s.IPA.String)
+	}
+	return s.Desc
+}
+
+// unicode2string converts code points in the format used in the IPA UNICODE column (e.g., U+0251U+02D0) to a string
+func unicode2string(u string) (string, error) {
+	if !strings.HasPrefix(u, "U+") {
+		return "", fmt.Errorf("invalid unicode '%s' (expected code points, e.g. U+0061)", u)
+	}
+	var res strings.Builder
+	for _, cp := range strings.Split(u, "U+")[1:] {
+		r, err := strconv.ParseUint(cp, 16, 32)
+		if err != nil || len(cp) < 4 || len(cp) > 6 {
+			return "", fmt.Errorf("invalid code point 'U+%s' in '%s'", cp, u)
+		}
+		res.WriteRune(rune(r))
+	}
+	return res.String(), nil
+}
@@ -0,0 +1,83 @@
+package symbolset
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_Symbol_Description(t *testing.T) {
+	input := `DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY
+	a:	aː	U+0061U+02D0	Syllabic
+	t_h	tʰ	U+0074U+02B0	NonSyllabic
+voiced	d	d	U+0064	NonSyllabic
+	"	ˈ	U+02C8	Stress
+	.	.	U+002E	SyllableDelimiter
+phoneme delimiter	 			PhonemeDelimiter
+`
+	ss, err := ReadSymbolSet("ss", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadSymbolSet() didn't expect error here : %v", err)
+	}
+	for _, test := range []struct {
+		symbol, desc string
+	}{
+		{"a:", "open front unrounded vowel (long)"},
+		{"t_h", "voiceless alveolar plosive, aspirated"},
+		{"d", "voiced"},
+		{"\"", "primary stress"},
+		{".", ""},
+	} {
+		sym, err := ss.Get(test.symbol)
+		if err != nil {
+			t.Errorf("Get() didn't expect error here : %v", err)
+			continue
+		}
+		if sym.Description() != test.desc {
+			t.Errorf("symbol /%s/: expected description '%s', got '%s'", test.symbol, test.desc, sym.Description())
+		}
+	}
+
+	// the descriptions are written as they were read
+	var buf bytes.Buffer
+	if err := ss.WriteSym(&buf); err != nil {
+		t.Fatalf("WriteSym() didn't expect error here : %v", err)
+	}
+	if buf.String() != input {
+		t.Errorf("expected /%s/, got /%s/", input, buf.String())
+	}
+}
+
+func Test_unicode2string(t *testing.T) {
+	for input, exp := range map[string]string{
+		"U+0061":       "a",
+		"U+0251U+02D0": "ɑː",
+		"U+1F600":      "😀",
+	} {
+		got, err := unicode2string(input)
+		if err != nil {
+			t.Errorf("unicode2string(%s) didn't expect error here : %v", input, err)
+		} else if got != exp {
+			t.Errorf("unicode2string(%s) expected /%s/, got /%s/", input, exp, got)
+		}
+	}
+	for _, input := range []string{"0061", "U+0251:", "U+61", "U+0061 U+0062", "U+XYZW"} {
+		if got, err := unicode2string(input); err == nil {
+			t.Errorf("unicode2string(%s) expected error here, got /%s/", input, got)
+		}
+	}
+}
+
+func Test_NewSymbolSet_IncorrectIPAUnicodeIsDescribed(t *testing.T) {
+	symbols := []Symbol{
+		{String: "g", Cat: NonSyllabic, IPA: IPASymbol{String: "g", Unicode: "U+0261"}},
+		{String: " ", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	_, err := NewSymbolSet("ss", symbols)
+	if err == nil {
+		t.Fatalf("NewSymbolSet() expected ipa/unicode error here")
+	}
+	if exp := "(/ɡ/: voiced velar plosive)"; !strings.Contains(err.Error(), exp) {
+		t.Errorf("expected error to contain %s, got %v", exp, err)
+	}
+}
@@ -19,8 +19,70 @@ Sample lines (Swedish Wikispeech SAMPA):
 
 Note that the header is required on the first line. As you can see in the examples, the IPA UNICODE is specified on the format U+<NUMBER> (no space between symbols in sequence).
 
+An optional FEATURES column can be added to specify phonological features for each symbol, as a comma separated list. Binary features are prefixed with + or -, privative features have no prefix:
+
+	DESCRIPTION          SYMBOL   IPA	 IPA UNICODE          CATEGORY      FEATURES
+	pol                  p        p  	 U+0070               NonSyllabic   -son,-voice,stop,labial
+	bok                  b        b  	 U+0062               NonSyllabic   -son,+voice,stop,labial
+
+Symbols with certain features can be retrieved using SymbolSet.WithFeatures, e.g. ss.WithFeatures("-voice", "stop") for all voiceless stops.
+
+Optional metadata can be added using directive lines, with the directive name and the value separated by a tab: TYPE (CMU, SAMPA, IPA or Other), LANGUAGE (BCP 47 language tag), VERSION, DESCRIPTION, LICENSE and SOURCE. For example:
+
+	TYPE                 SAMPA
+	LANGUAGE             sv-SE
+	DESCRIPTION          NST X-SAMPA for Swedish
+
+If no TYPE is declared, the type is derived from the symbol set name.
+
+The placement of stress, accent and tone symbols can be declared using a STRESS_PLACEMENT line. Legal values are BeforeSyllable, BeforeNucleus, AfterNucleus (stress attached to the syllabic phoneme, like the CMU stress digits) and ToneLetter (stress or tone following the syllable):
+
+	STRESS_PLACEMENT     AfterNucleus
+
+If no stress placement is declared, stress is filtered according to the symbol set type.
+
+IPA strings can be normalized to a Unicode normalization form, so that precomposed and decomposed characters (such as ç and c + U+0327) are treated alike. This applies to the IPA symbols of the symbol set, and to IPA input transcriptions (see SymbolSet.NormalizeIPAInput). By default, no normalization is made, and the IPA strings are used as declared. NFD (recommended for new symbol sets) or NFC can be declared using a UNICODE_NORMALIZATION line:
+
+	UNICODE_NORMALIZATION     NFD
+
+Equivalent characters in IPA input, such as the tie bars ⁀, ͡, ‿ and ͜, or g and ɡ, are replaced by the one used in the symbol set.
+
+General IPA, such as transcriptions from Wikipedia or Wiktionary, can be mapped into a symbol set using SymbolSet.ConvertFromGeneralIPA (or the mapping server's /mapper/import_ipa). Segments that are not in the symbol set's IPA inventory (e.g., aspirated or dental consonants) are replaced by the closest symbol, by dropping diacritics or using the phonetic descriptions in the IPA character database, and each approximation is reported. White space in the input is mapped to the word delimiter of the symbol set.
+
+If the phoneme delimiter is the empty string, transcriptions are split using longest match. Since such transcriptions can be ambiguous (e.g., "" vs " + "), all possible splits can be retrieved using SymbolSet.Segmentations. Ambiguous symbol combinations found in the symbol inventory are reported by SymbolSet.Ambiguities.
+
+Alternative spellings of a symbol can be declared using ALIAS lines, and legacy spellings using DEPRECATED lines, with the symbol type (SYMBOLS or IPA), the alias and the canonical form:
+
+	ALIAS                IPA       g     ɡ
+	DEPRECATED           SYMBOLS   s'    s
+
+Aliases are accepted as input when splitting and converting transcriptions. SymbolSet.Normalize rewrites aliases into their canonical form, and reports the changes made.
+
+Length marks and diacritics that combine with many base symbols can be declared using MODIFIER lines, with the modifier symbol, its IPA and the categories of the base symbols it attaches to, instead of listing each combination as a separate symbol:
+
+	MODIFIER             :         ː     Syllabic
+	MODIFIER             ~         ̃     Syllabic,NonSyllabic
+
+The base symbols are combined with the modifiers (in the declared order) into composed symbols, e.g. a~: for /ãː/, that are valid symbols when splitting, converting and validating transcriptions (see SymbolSet.ComposedSymbols). Composed symbols with the same symbol or IPA string as a symbol listed explicitly are not created. Since every combination is created when the symbol set is loaded, the number of composed symbols is limited (1024): symbol sets with more combinations are rejected.
+
+SymbolSet.Validate checks a transcription for unknown symbols, deprecated symbols, misplaced stress and empty syllables. Each Diagnostic has the position of the offending token in the input string, an error code, and a suggested fix (if any).
+
+SymbolSet.CheckWellFormedness checks the structure of a transcription: each syllable has exactly one syllabic nucleus, each word has at most one primary stress, stress is placed at the start of the syllable (or as declared by STRESS_PLACEMENT), delimiters are not doubled, and compound delimiters do not sit inside syllables. Rules can be disabled for a symbol set using DISABLE_RULE lines:
+
+	DISABLE_RULE         SinglePrimaryStress
+
+For symbols with an empty description in the .sym file, Symbol.Description returns a description generated from their IPA, using the IPA character database in the sub package 'ipa' (see DescribeIPA). The database is built from the IPA table served by the mapping server, and has the Unicode name, phonetic description, IPA number and class (vowel, consonant, diacritic or suprasegmental) of each IPA character.
+
+A new symbol set can be generated from a list of IPA phonemes using NewSymbolSetSkeleton (or the ssgen command, cmd/ssgen), optionally transliterated using another symbol set, such as the built-in X-SAMPA. Categories are inferred from the IPA (see InferCategory).
+
+A .sym file can be rewritten in canonical form using FormatSym, or the ssfmt command (cmd/ssfmt). The IPA UNICODE column is recomputed from the IPA column, stray white space is removed, and the symbols can optionally be grouped by category. Comments, tests and directives are kept. The -check flag of ssfmt lists files that are not in canonical form.
+
+Possible problems in a symbol set, such as duplicate symbols, IPA characters not found in the IPA character database, and categories that contradict the IPA, are reported by Lint. Each issue has a severity (info, warning or error), and LoadSymbolSetStrict can be used to fail loading on issues of a certain severity. The sslint command (cmd/sslint) runs the linter on .sym files.
+
 Each symbol set has a name, extracted from the .sym file name.
 
+A symbol set can be written back to .sym format using SymbolSet.WriteSym, which keeps the comments, tests, aliases and directives of the original file. Symbol sets can also be written as JSON (SymbolSet.WriteJSON, loaded using LoadSymbolSetJSON) or CSV (SymbolSet.WriteCSV).
+
 Legal categories (pre-defined in code):
 
 	Syllabic: syllabic phonemes (typically vowels and syllabic consonants)
@@ -39,6 +101,24 @@ Legal categories (pre-defined in code):
 
 	WordDelimiter: word delimiters
 
+	Tone: tone symbols that are not stress or accent symbols (tone letters, tone numbers, downstep, etc)
+
+	Length: length marks written as separate symbols
+
+	Diacritic: diacritics written as separate symbols
+
+	Pause: pause symbols (e.g., silence markers)
+
+	IntonationBoundary: intonation group boundaries (e.g., IPA | and ‖)
+
+Length marks and diacritics belong to the preceding phoneme: stress placed after the syllabic phoneme (by the IPA stress filters, or a declared stress placement) is also placed after them, and they are included in the onsets over which stress is moved. Tone symbols are never moved by the stress filters, and are neither phonemes nor stress when checking syllables. Pauses and intonation boundaries split transcriptions like word delimiters: stress is never moved across them, the syllabifier keeps them, and empty syllables next to them are not reported by Validate.
+Phrase transcriptions are split into words at word delimiters, pauses and intonation boundaries (see SymbolSet.SplitPhrase and SymbolSet.SplitWords). ConvertToInternalIPA and ConvertFromInternalIPA filter and map each word separately, so that filters and stress placement never apply across a word boundary. In converters, the ANCHORS WORD directive makes the rules apply to each word separately (see the converter package).
+Converters treat the new categories like any other symbol: symbols that are missing in the target symbol set need a conversion rule, and are reported by the converter's validation otherwise.
+
+Compound delimiters can be checked against the decompounded orthography using CheckCompoundAlignment (e.g., fot+boll, with compound boundaries marked using a delimiter, such as DefaultDecompoundDelimiter). The letters are aligned with the phonemes of the transcription, and missing, extra and misaligned compound delimiters are reported. CheckCompoundAlignmentLexicon checks all entries in a tab separated lexicon file, and the sscompound command (cmd/sscompound) runs the check on lexicon files from the command line.
+
+Language independent reference symbol sets for X-SAMPA, Kirshenbaum (ASCII-IPA) and ARPAbet are embedded in the sub package 'builtin', and can be registered in a mapper service using mapper.Service.LoadBuiltins. The mapping server loads them along with the symbol sets in its symbol set folder (a .sym file with the same name takes precedence).
+
 For real world examples (used for unit tests), see the test_data folder: https://github.com/stts-se/pronlex/tree/master/symbolset/test_data
 */
 package symbolset
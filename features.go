@@ -0,0 +1,96 @@
+package symbolset
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// phonological features for symbols
+
+// Features holds the phonological features of a symbol, as a comma separated list. Binary features are prefixed with + or - (e.g., +voice, -son), privative features have no prefix (e.g., labial).
+// Features is a string type, so that Symbol values can still be compared using ==.
+type Features string
+
+var featureRe = regexp.MustCompile("^[+-]?[a-zA-Z][a-zA-Z0-9_]*$")
+
+// ParseFeatures parses a comma separated list of features, such as "+voice,-son,labial"
+func ParseFeatures(s string) (Features, error) {
+	var res []string
+	if len(strings.TrimSpace(s)) == 0 {
+		return "", nil
+	}
+	seen := make(map[string]string)
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if !featureRe.MatchString(f) {
+			return "", fmt.Errorf("invalid feature '%s' in '%s'", f, s)
+		}
+		name := featureName(f)
+		if prev, ok := seen[name]; ok {
+			return "", fmt.Errorf("feature '%s' is specified more than once in '%s' (found %s and %s)", name, s, prev, f)
+		}
+		seen[name] = f
+		res = append(res, f)
+	}
+	return Features(strings.Join(res, ",")), nil
+}
+
+func featureName(f string) string {
+	return strings.TrimLeft(f, "+-")
+}
+
+// Has checks if the features include the feature spec. A spec with a + or - prefix requires a binary feature with the same value.
+// A spec without prefix matches both privative and positive binary features (e.g., 'labial' matches 'labial' and '+labial').
+func (fs Features) Has(spec string) bool {
+	for _, f := range fs.List() {
+		if f == spec {
+			return true
+		}
+		if !strings.HasPrefix(spec, "+") && !strings.HasPrefix(spec, "-") && f == "+"+spec {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the features as a slice of strings
+func (fs Features) List() []string {
+	if len(fs) == 0 {
+		return []string{}
+	}
+	return strings.Split(string(fs), ",")
+}
+
+// HasFeatures checks if the symbol has all of the specified features
+func (s Symbol) HasFeatures(specs ...string) bool {
+	for _, spec := range specs {
+		if !s.Features.Has(spec) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithFeatures returns all symbols in the symbol set that have all of the specified features.
+// Each spec can be a single feature or a comma separated list, such as "-voice,+stop".
+func (ss SymbolSet) WithFeatures(specs ...string) ([]Symbol, error) {
+	var query []string
+	for _, spec := range specs {
+		fs, err := ParseFeatures(spec)
+		if err != nil {
+			return nil, err
+		}
+		query = append(query, fs.List()...)
+	}
+	if len(query) == 0 {
+		return nil, fmt.Errorf("no features specified")
+	}
+	var res = make([]Symbol, 0)
+	for _, s := range ss.Symbols {
+		if s.HasFeatures(query...) {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package symbolset
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ParseFeatures(t *testing.T) {
+	fs, err := ParseFeatures("+voice, -son,labial")
+	if err != nil {
+		t.Errorf("ParseFeatures() didn't expect error here : %v", err)
+		return
+	}
+	testEqStrings(t, []string{"+voice", "-son", "labial"}, fs.List())
+	if fs != "+voice,-son,labial" {
+		t.Errorf(fsExp, "+voice,-son,labial", fs)
+	}
+
+	fs, err = ParseFeatures("")
+	if err != nil {
+		t.Errorf("ParseFeatures() didn't expect error here : %v", err)
+		return
+	}
+	if len(fs.List()) != 0 {
+		t.Errorf(fsExp, "", fs)
+	}
+
+	for _, s := range []string{"+voice,-voice", "+voice,voice", "++voice", "voice,", "1st"} {
+		_, err = ParseFeatures(s)
+		if err == nil {
+			t.Errorf("ParseFeatures() expected error for input '%s'", s)
+		}
+	}
+}
+
+func Test_Features_Has(t *testing.T) {
+	fs := Features("+voice,-son,labial")
+	for _, spec := range []string{"+voice", "voice", "-son", "labial"} {
+		if !fs.Has(spec) {
+			t.Errorf("expected %v to have feature %s", fs, spec)
+		}
+	}
+	for _, spec := range []string{"-voice", "son", "+son", "+labial", "-labial", "coronal"} {
+		if fs.Has(spec) {
+			t.Errorf("expected %v not to have feature %s", fs, spec)
+		}
+	}
+}
+
+func Test_WithFeatures(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa-features.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	voicelessStops, err := ss.WithFeatures("-voice", "stop")
+	if err != nil {
+		t.Errorf("WithFeatures() didn't expect error here : %v", err)
+		return
+	}
+	var result []string
+	for _, s := range voicelessStops {
+		result = append(result, s.String)
+	}
+	testEqStrings(t, []string{"p", "t", "rt", "k"}, result)
+
+	longRoundedVowels, err := ss.WithFeatures("+syl,+round,+long")
+	if err != nil {
+		t.Errorf("WithFeatures() didn't expect error here : %v", err)
+		return
+	}
+	result = []string{}
+	for _, s := range longRoundedVowels {
+		result = append(result, s.String)
+	}
+	testEqStrings(t, []string{"}:", "u:", "y:", "2:", "9:", "o:"}, result)
+
+	_, err = ss.WithFeatures("")
+	if err == nil {
+		t.Errorf("WithFeatures() expected error here")
+	}
+}
+
+func Test_ReadSymbolSet_FieldCount(t *testing.T) {
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{header + "\nsil	i:	iː	U+0069U+02D0	Syllabic	+syl\n", "expected 5 fields, found 6"},
+		{headerWithFeatures + "\nsil	i:	iː	U+0069U+02D0\n", "expected 5 or 6 fields, found 4"},
+		{"DESCRIPTION	SYMBOL\n", "expected header '" + header + "' or '" + headerWithFeatures + "'"},
+	}
+	for _, test := range tests {
+		_, err := ReadSymbolSet("test", strings.NewReader(test.input))
+		if err == nil {
+			t.Errorf("expected error for %q", test.input)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expect) {
+			t.Errorf(fsExp, test.expect, err)
+		}
+	}
+}
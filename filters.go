@@ -30,6 +30,103 @@ func postFilter(ss SymbolSet, trans string, toType Type) (string, error) {
 	return trans, nil
 }
 
+// filterBeforeMapping filters a transcription in the symbol set's own notation before it is split and mapped.
+// If the symbol set has a declared stress placement, it is used for filtering. Otherwise, the filters for the symbol set's Type are used.
+func filterBeforeMapping(ss SymbolSet, trans string) (string, error) {
+	if ss.StressPlacement != StressUndefined {
+		return filterBeforeMappingFromStressPlacement(ss, trans)
+	}
+	return preFilter(ss, trans, ss.Type)
+}
+
+// filterAfterMapping filters a transcription that has been mapped into the symbol set's own notation.
+// If the symbol set has a declared stress placement, it is used for filtering. Otherwise, the filters for the symbol set's Type are used.
+func filterAfterMapping(ss SymbolSet, trans string) (string, error) {
+	if ss.StressPlacement != StressUndefined {
+		return filterAfterMappingToStressPlacement(ss, trans)
+	}
+	return postFilter(ss, trans, ss.Type)
+}
+
+// filters holds the regular expressions used for stress/accent placement, compiled once for each symbol set
+type filters struct {
+	// IPA: ˈba`ŋ.ka => ˈ`baŋ.ka
+	fromInternalIPAAccentII *regexp.Regexp
+
+	// IPA: /t°Ɑlsyn`tEs/ => /t°Ɑlsynt`Es/
+	toIPAStress *regexp.Regexp
+	// IPA: /'`pa.pa/ => /'pa`.pa/
+	toIPAAccentII *regexp.Regexp
+
+	// IPA: /ə.ba⁀ʊˈt/ => /ə.ˈba⁀ʊt/
+	toInternalIPAStressAfterSyllabic *regexp.Regexp
+	// IPA: /ə.bˈa⁀ʊt/ => /ə.ˈba⁀ʊt/
+	toInternalIPAStressBeforeSyllabic *regexp.Regexp
+	// IPA: /'`pa.pa/ => /'pa`.pa/
+	toInternalIPAAccentII *regexp.Regexp
+
+	// CMU: 1 t r ae => t r ae1
+	toCMUStress *regexp.Regexp
+
+	// true if the symbol set has a non-empty syllable delimiter
+	hasSyllDelim bool
+}
+
+func compileFilter(s string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
+	}
+	return re, nil
+}
+
+// buildFilters compiles the stress/accent filters for the symbol set. The symbol set's regexps must be initialized before calling this function.
+func buildFilters(ss SymbolSet) (filters, error) {
+	var res filters
+	var err error
+	for _, s := range filterSymbolsByCat(ss.Symbols, []SymbolCat{SyllableDelimiter}) {
+		if len(s.String) > 0 {
+			res.hasSyllDelim = true
+		}
+	}
+	// length marks and diacritics written as separate symbols belong to the preceding phoneme, so they are included in the onsets and phoneme sequences below
+	onset := filterSymbolsByCat(ss.allSymbols(), []SymbolCat{NonSyllabic, Length, Diacritic})
+	onsetRe, err := buildRegexp(onset)
+	if err != nil {
+		return res, err
+	}
+	ipaOnsetRe, err := buildIPARegexp(onset)
+	if err != nil {
+		return res, err
+	}
+	ipaSegmentRe, err := buildIPARegexp(filterSymbolsByCat(ss.allSymbols(), []SymbolCat{Syllabic, NonSyllabic, Stress, Length, Diacritic}))
+	if err != nil {
+		return res, err
+	}
+	if res.fromInternalIPAAccentII, err = compileFilter(ipaAccentI + "(" + ipaSegmentRe.String() + "+)" + ipaAccentII); err != nil {
+		return res, err
+	}
+	if res.toIPAStress, err = compileFilter("(" + ss.StressRe.String() + ")(" + onsetRe.String() + "*)(" + ss.SyllabicRe.String() + ")"); err != nil {
+		return res, err
+	}
+	if res.toIPAAccentII, err = compileFilter(ipaAccentI + ipaAccentII + "(" + onsetRe.String() + "*)(" + ss.SyllabicRe.String() + ")"); err != nil {
+		return res, err
+	}
+	if res.toInternalIPAStressAfterSyllabic, err = compileFilter("(" + ipaOnsetRe.String() + "*)(" + ss.ipaSyllabicRe.String() + ")(" + ipaIndepStressRe + ")"); err != nil {
+		return res, err
+	}
+	if res.toInternalIPAStressBeforeSyllabic, err = compileFilter("(" + ipaOnsetRe.String() + "*)(" + ipaIndepStressRe + ")(" + ss.ipaSyllabicRe.String() + ")"); err != nil {
+		return res, err
+	}
+	if res.toInternalIPAAccentII, err = compileFilter(ipaAccentI + ipaAccentII + "(" + ipaOnsetRe.String() + "*)(" + ss.ipaSyllabicRe.String() + ")"); err != nil {
+		return res, err
+	}
+	if res.toCMUStress, err = compileFilter("([012]) ((?:" + onsetRe.String() + " )*)(" + ss.SyllabicRe.String() + ")"); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
 var ipaIndepStressRe = fmt.Sprintf("[%s%s]", ipaAccentI, ipaSecStress)
 var ipaAccentI = "\u02C8"
 var ipaAccentII = "\u0300"
@@ -43,12 +140,7 @@ func filterBeforeMappingFromInternalIPA(ss SymbolSet, trans string) (string, err
 	// IPA: ˈba`ŋ.ka => ˈ`baŋ.ka"
 	// IPA: ˈɑ̀ː.pa => ˈ`ɑː.pa
 	trans = strings.Replace(trans, ipaAccentII+ipaLength, ipaLength+ipaAccentII, -1)
-	s := ipaAccentI + "(" + ss.ipaPhonemeRe.String() + "+)" + ipaAccentII
-	repl, err := regexp.Compile(s)
-	if err != nil {
-		return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-	}
-	res := repl.ReplaceAllString(trans, ipaAccentI+ipaAccentII+"$1")
+	res := ss.filters.fromInternalIPAAccentII.ReplaceAllString(trans, ipaAccentI+ipaAccentII+"$1")
 	return res, nil
 }
 
@@ -96,24 +188,13 @@ func filterAfterMappingToIPA(ss SymbolSet, trans string) (string, error) {
 	// }
 
 	// IPA: /t°Ɑlsyn`tEs/ => /t°Ɑlsynt`Es/
-	s := "(" + ss.StressRe.String() + ")(" + ss.NonSyllabicRe.String() + "*)(" + ss.SyllabicRe.String() + ")"
-	repl, err := regexp.Compile(s)
-	if err != nil {
-		return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-	}
-	trans = repl.ReplaceAllString(trans, "$2$1$3")
+	trans = ss.filters.toIPAStress.ReplaceAllString(trans, "$2$1$3")
 
 	// IPA: əs.ˈ̀̀e ...
 	// IPA: /'`pa.pa/ => /'pa`.pa/
 	accentIIConditionForAfterMapping := ipaAccentI + ipaAccentII
 	if strings.Contains(trans, accentIIConditionForAfterMapping) {
-		s := ipaAccentI + ipaAccentII + "(" + ss.NonSyllabicRe.String() + "*)(" + ss.SyllabicRe.String() + ")"
-		repl, err := regexp.Compile(s)
-		if err != nil {
-			return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-		}
-		res := repl.ReplaceAllString(trans, ipaAccentI+"$1$2"+ipaAccentII)
-		trans = res
+		trans = ss.filters.toIPAAccentII.ReplaceAllString(trans, ipaAccentI+"$1$2"+ipaAccentII)
 	}
 	// IPA: /'paː`.pa/ => /'pa`ː.pa/
 	trans = strings.Replace(trans, ipaLength+ipaAccentII, ipaAccentII+ipaLength, -1)
@@ -123,13 +204,7 @@ func filterAfterMappingToIPA(ss SymbolSet, trans string) (string, error) {
 func filterAfterMappingToInternalIPA(ss SymbolSet, trans string) (string, error) {
 
 	// filter stress differently if the symbol set has a syllable delimiter
-	hasSyllDelim := false
-	for _, s := range filterSymbolsByCat(ss.Symbols, []SymbolCat{SyllableDelimiter}) {
-		if len(s.String) > 0 {
-			hasSyllDelim = true
-		}
-	}
-	if !hasSyllDelim {
+	if !ss.filters.hasSyllDelim {
 		return trans, nil
 	}
 	// create an error if the input transcription contains more than one syllabic, but no syllable delimiter
@@ -142,54 +217,31 @@ func filterAfterMappingToInternalIPA(ss SymbolSet, trans string) (string, error)
 	// }
 
 	// IPA: /ə.ba⁀ʊˈt/ => /ə.ˈba⁀ʊt/
-	s := "(" + ss.ipaNonSyllabicRe.String() + "*)(" + ss.ipaSyllabicRe.String() + ")(" + ipaIndepStressRe + ")"
-	repl, err := regexp.Compile(s)
-	if err != nil {
-		return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-	}
-	trans = repl.ReplaceAllString(trans, "$3$1$2")
+	trans = ss.filters.toInternalIPAStressAfterSyllabic.ReplaceAllString(trans, "$3$1$2")
 
 	// IPA: /ə.bˈa⁀ʊt/ => /ə.ˈba⁀ʊt/
-	s = "(" + ss.ipaNonSyllabicRe.String() + "*)(" + ipaIndepStressRe + ")(" + ss.ipaSyllabicRe.String() + ")"
-	repl, err = regexp.Compile(s)
-	if err != nil {
-		return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-	}
-	trans = repl.ReplaceAllString(trans, "$2$1$3")
+	trans = ss.filters.toInternalIPAStressBeforeSyllabic.ReplaceAllString(trans, "$2$1$3")
 
 	// IPA: əs.ˈ̀̀e ...
 	// IPA: /'`pa.pa/ => /'pa`.pa/
 	accentIIConditionForAfterMapping := ipaAccentI + ipaAccentII
 	if strings.Contains(trans, accentIIConditionForAfterMapping) {
-		s := ipaAccentI + ipaAccentII + "(" + ss.ipaNonSyllabicRe.String() + "*)(" + ss.ipaSyllabicRe.String() + ")"
-		repl, err := regexp.Compile(s)
-		if err != nil {
-			return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-		}
-		res := repl.ReplaceAllString(trans, ipaAccentI+"$1$2"+ipaAccentII)
-		trans = res
+		trans = ss.filters.toInternalIPAAccentII.ReplaceAllString(trans, ipaAccentI+"$1$2"+ipaAccentII)
 	}
 	// IPA: /'paː`.pa/ => /'pa`ː.pa/
 	trans = strings.Replace(trans, ipaLength+ipaAccentII, ipaAccentII+ipaLength, -1)
 	return trans, nil
 }
 
+var cmuStressRe = regexp.MustCompile("([^ ]+)([012])")
+
 func filterBeforeMappingFromCMU(ss SymbolSet, trans string) (string, error) {
-	re, err := regexp.Compile("([^ ]+)([012])")
-	if err != nil {
-		return "", err
-	}
-	trans = re.ReplaceAllString(trans, "$2 $1")
+	trans = cmuStressRe.ReplaceAllString(trans, "$2 $1")
 	return trans, nil
 }
 
 func filterAfterMappingToCMU(ss SymbolSet, trans string) (string, error) {
-	s := "([012]) ((?:" + ss.NonSyllabicRe.String() + " )*)(" + ss.SyllabicRe.String() + ")"
-	repl, err := regexp.Compile(s)
-	if err != nil {
-		return "", fmt.Errorf("couldn't compile regexp from string '%s' : %w", s, err)
-	}
-	trans = repl.ReplaceAllString(trans, "$2$3$1")
+	trans = ss.filters.toCMUStress.ReplaceAllString(trans, "$2$3$1")
 
 	trans = strings.Replace(trans, " 1", "1", -1)
 	trans = strings.Replace(trans, " 2", "2", -1)
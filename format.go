@@ -0,0 +1,132 @@
+package symbolset
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// formatting of .sym files
+
+// FormatOptions are the options for FormatSym
+type FormatOptions struct {
+	// GroupByCategory groups the symbols by category (Syllabic, NonSyllabic, Stress, and then the delimiters), keeping the order within each category.
+	// Comment lines directly preceding a symbol line are moved along with the symbol.
+	GroupByCategory bool
+}
+
+// FormatSym reads a symbol set in .sym format, and returns it in canonical form:
+// the IPA UNICODE column is recomputed from the IPA column, white space is trimmed from all fields (except for white space symbols, such as a space phoneme delimiter),
+// carriage returns and trailing empty lines are removed, and (optionally) the symbols are grouped by category.
+// Comments, tests and directives are kept.
+// Since the input is not loaded as a symbol set, files with incorrect IPA UNICODE columns can be formatted.
+func FormatSym(r io.Reader, opts FormatOptions) ([]byte, error) {
+	type formatLine struct {
+		text string
+		// symbol lines have a category, other lines have -1
+		cat SymbolCat
+	}
+	var lines []formatLine
+	s := bufio.NewScanner(r)
+	n := 0
+	for s.Scan() {
+		n++
+		l := strings.TrimRight(s.Text(), "\r")
+		if n == 1 {
+			l = strings.TrimSpace(strings.TrimPrefix(l, "\ufeff"))
+			if l != header && l != headerWithFeatures {
+				return nil, fmt.Errorf("expected header '%s', found '%s'", header, l)
+			}
+			lines = append(lines, formatLine{text: l, cat: -1})
+			continue
+		}
+		trimmed := strings.TrimSpace(l)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+			lines = append(lines, formatLine{text: trimmed, cat: -1})
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		for i, f := range fs {
+			fs[i] = trimIfNeeded(f)
+		}
+		l = strings.Join(fs, "\t")
+		if isTestLine(l) || isStressPlacementLine(l) || isNormalizationLine(l) || isDisableRuleLine(l) || isAliasLine(l) || isModifierLine(l) || isMetadataLine(l) {
+			lines = append(lines, formatLine{text: l, cat: -1})
+			continue
+		}
+		if len(fs) != 5 && len(fs) != 6 {
+			return nil, fmt.Errorf("invalid input line %d (expected %d fields, found %d) : %s", n, 5, len(fs), l)
+		}
+		// the description and IPA may be empty, but not white space only
+		fs[0] = strings.TrimSpace(fs[0])
+		fs[2] = strings.TrimSpace(fs[2])
+		cat, err := symbolCatFromString(fs[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid category on line %d : %w", n, err)
+		}
+		fs[3] = ""
+		if len(fs[2]) > 0 {
+			fs[3] = string2unicode(fs[2])
+		}
+		if len(fs) == 6 && len(fs[5]) == 0 {
+			fs = fs[:5]
+		}
+		lines = append(lines, formatLine{text: strings.Join(fs, "\t"), cat: cat})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	for len(lines) > 0 && lines[len(lines)-1].text == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("expected header '%s', found empty input", header)
+	}
+
+	if opts.GroupByCategory {
+		// symbol lines, with the comment lines directly preceding them
+		type block struct {
+			lines []formatLine
+			cat   SymbolCat
+		}
+		var blocks []block
+		var rest []formatLine
+		first := -1
+		var comments []formatLine
+		for _, l := range lines[1:] {
+			switch {
+			case l.cat >= 0:
+				if first < 0 {
+					first = len(rest)
+				}
+				blocks = append(blocks, block{lines: append(comments, l), cat: l.cat})
+				comments = nil
+			case strings.HasPrefix(l.text, "#"):
+				comments = append(comments, l)
+			default:
+				rest = append(rest, comments...)
+				rest = append(rest, l)
+				comments = nil
+			}
+		}
+		rest = append(rest, comments...)
+		if first >= 0 {
+			sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].cat < blocks[j].cat })
+			var symbols []formatLine
+			for _, b := range blocks {
+				symbols = append(symbols, b.lines...)
+			}
+			rest = append(rest[:first], append(symbols, rest[first:]...)...)
+		}
+		lines = append(lines[:1], rest...)
+	}
+
+	var res strings.Builder
+	for _, l := range lines {
+		res.WriteString(l.text)
+		res.WriteString("\n")
+	}
+	return []byte(res.String()), nil
+}
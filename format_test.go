@@ -0,0 +1,140 @@
+package symbolset
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_FormatSym(t *testing.T) {
+	input := strings.Join([]string{
+		header + " \r",
+		"TYPE\tSAMPA ",
+		"# consonants\t\t\t",
+		" voiceless t\tt \tt\tU+0074\tNonSyllabic",
+		"vowel a\ta\ta\tU+0251\tSyllabic",
+		"",
+		"# stress",
+		"primary stress\t\"\tˈ\t\tStress",
+		"  \t\t",
+		"# long vowel",
+		"long a\ta:\taː\tU+0061:\t Syllabic",
+		"syllable delimiter\t.\t.\tU+002E\tSyllableDelimiter",
+		"phoneme delimiter\t \t\t\tPhonemeDelimiter",
+		"TEST\tACCEPT\tSYMBOLS\t\" t a: ",
+		"",
+		"",
+	}, "\n")
+
+	exp := strings.Join([]string{
+		header,
+		"TYPE\tSAMPA",
+		"# consonants",
+		"voiceless t\tt\tt\tU+0074\tNonSyllabic",
+		"vowel a\ta\ta\tU+0061\tSyllabic",
+		"",
+		"# stress",
+		"primary stress\t\"\tˈ\tU+02C8\tStress",
+		"",
+		"# long vowel",
+		"long a\ta:\taː\tU+0061U+02D0\tSyllabic",
+		"syllable delimiter\t.\t.\tU+002E\tSyllableDelimiter",
+		"phoneme delimiter\t \t\t\tPhonemeDelimiter",
+		"TEST\tACCEPT\tSYMBOLS\t\" t a:",
+		"",
+	}, "\n")
+	res, err := FormatSym(strings.NewReader(input), FormatOptions{})
+	if err != nil {
+		t.Fatalf("FormatSym() didn't expect error here : %v", err)
+	}
+	if string(res) != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, res)
+	}
+	if _, err := ReadSymbolSet("ss", bytes.NewReader(res)); err != nil {
+		t.Errorf("ReadSymbolSet() didn't expect error here : %v", err)
+	}
+
+	// grouped by category
+	expGrouped := strings.Join([]string{
+		header,
+		"TYPE\tSAMPA",
+		"vowel a\ta\ta\tU+0061\tSyllabic",
+		"# long vowel",
+		"long a\ta:\taː\tU+0061U+02D0\tSyllabic",
+		"# consonants",
+		"voiceless t\tt\tt\tU+0074\tNonSyllabic",
+		"# stress",
+		"primary stress\t\"\tˈ\tU+02C8\tStress",
+		"phoneme delimiter\t \t\t\tPhonemeDelimiter",
+		"syllable delimiter\t.\t.\tU+002E\tSyllableDelimiter",
+		"",
+		"",
+		"TEST\tACCEPT\tSYMBOLS\t\" t a:",
+		"",
+	}, "\n")
+	res, err = FormatSym(strings.NewReader(input), FormatOptions{GroupByCategory: true})
+	if err != nil {
+		t.Fatalf("FormatSym() didn't expect error here : %v", err)
+	}
+	if string(res) != expGrouped {
+		t.Errorf("expected:\n%s\ngot:\n%s", expGrouped, res)
+	}
+
+	// the canonical form is stable
+	for _, opts := range []FormatOptions{{}, {GroupByCategory: true}} {
+		res, _ := FormatSym(strings.NewReader(input), opts)
+		again, err := FormatSym(bytes.NewReader(res), opts)
+		if err != nil {
+			t.Fatalf("FormatSym() didn't expect error here : %v", err)
+		}
+		if !bytes.Equal(res, again) {
+			t.Errorf("expected canonical form to be stable, got:\n%s", again)
+		}
+	}
+}
+
+func Test_FormatSym_Errors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"DESCRIPTION\tSYMBOL\tIPA\n",
+		header + "\nvowel a\ta\ta\tU+0061\n",
+		header + "\nvowel a\ta\ta\tU+0061\tVowel\n",
+	} {
+		if _, err := FormatSym(strings.NewReader(input), FormatOptions{}); err == nil {
+			t.Errorf("FormatSym() expected error for input %q", input)
+		}
+	}
+}
+
+func Test_FormatSym_TestData(t *testing.T) {
+	files, err := filepath.Glob("test_data/*.sym")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		input, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig, err := ReadSymbolSet("ss", bytes.NewReader(input))
+		if err != nil {
+			t.Fatalf("ReadSymbolSet() didn't expect error here : %v", err)
+		}
+		res, err := FormatSym(bytes.NewReader(input), FormatOptions{})
+		if err != nil {
+			t.Errorf("%s: FormatSym() didn't expect error here : %v", f, err)
+			continue
+		}
+		formatted, err := ReadSymbolSet("ss", bytes.NewReader(res))
+		if err != nil {
+			t.Errorf("%s: ReadSymbolSet() didn't expect error here : %v", f, err)
+			continue
+		}
+		if !reflect.DeepEqual(orig.Symbols, formatted.Symbols) {
+			t.Errorf("%s: expected the same symbols after formatting", f)
+		}
+	}
+}
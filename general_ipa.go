@@ -0,0 +1,451 @@
+package symbolset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stts-se/symbolset/ipa"
+)
+
+// import of general IPA (e.g., from Wikipedia or Wiktionary) into a symbol set
+
+// Approximation is a change made by ConvertFromGeneralIPA, for an input segment that is not in the symbol set's IPA inventory
+type Approximation struct {
+	// From is the segment in the input IPA (a base character with its diacritics)
+	From string `json:"from"`
+
+	// To is the IPA symbol(s) used instead, or an empty string if the segment was dropped
+	To string `json:"to"`
+
+	// ByteOffset and RuneOffset are the start positions of the segment in the input transcription
+	ByteOffset int `json:"byte_offset"`
+	RuneOffset int `json:"rune_offset"`
+
+	Message string `json:"message"`
+}
+
+func (a Approximation) String() string {
+	return fmt.Sprintf("'%s' at position %d: %s", a.From, a.RuneOffset, a.Message)
+}
+
+// maxDroppedModifiers is the max number of modifiers in a segment for which all combinations of dropped modifiers are tested
+const maxDroppedModifiers = 6
+
+// maxPhoneticDistance is the max distance between an input segment and the nearest symbol (see phoneticDistance)
+const maxPhoneticDistance = 3
+
+// ignoredGeneralIPA are characters that are silently removed from general IPA input (transcription brackets, and brackets for optional segments)
+const ignoredGeneralIPA = "/[]()"
+
+func isTieBar(r rune) bool {
+	return r == '͡' || r == '͜' || r == '⁀' || r == '‿'
+}
+
+// isModifier returns true for characters that modify the preceding base character: diacritics, modifier letters, length marks and tie bars
+func isModifier(r rune) bool {
+	if r == 'ː' || r == 'ˑ' || isTieBar(r) {
+		return true
+	}
+	return ipa.Classify(r) == ipa.Diacritic
+}
+
+// generalIPASegment returns the length of the segment at the start of s: the longest match in the IPA inventory (or a single base character), followed by modifiers.
+// The base character following a tie bar is included in the segment.
+func (ss SymbolSet) generalIPASegment(s string) int {
+	n := ss.ipaTrie.longestMatch(s)
+	if n == 0 {
+		_, n = utf8.DecodeRuneInString(s)
+	}
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !isModifier(r) {
+			break
+		}
+		n += size
+		if isTieBar(r) && n < len(s) {
+			_, size = utf8.DecodeRuneInString(s[n:])
+			n += size
+		}
+	}
+	return n
+}
+
+// ConvertFromGeneralIPA maps a general IPA transcription (as opposed to the symbol set's internal IPA) into the current symbol set.
+// Transcription brackets are removed, and the input is normalized using NormalizeIPAInput.
+// White space separates words, and is mapped to the symbol set's word delimiter. If the symbol set has no word delimiter, the words are joined using the phoneme delimiter, and each dropped word boundary is reported as an Approximation.
+// The input is split into segments (a base character with its diacritics, or a symbol in the IPA inventory with any extra diacritics), and each segment that is not in the IPA inventory is replaced by the closest symbol:
+// first, by dropping as few diacritics as possible; second, by the phonetically closest symbol, using the descriptions in the IPA character database; third, for affricates and other tied segments, by mapping each part separately.
+// Segments that cannot be mapped are dropped. Each change is reported as an Approximation.
+func (ss SymbolSet) ConvertFromGeneralIPA(trans string) (string, []Approximation, error) {
+	normalized, segments := ss.normalizeIPAInputWithSegments(trans)
+	var words [][]string
+	var res []string
+	var approximations []Approximation
+	// gaps[k] is the white space between words[k] and words[k+1]
+	var gaps []Approximation
+	for i := 0; i < len(normalized); {
+		r, size := utf8.DecodeRuneInString(normalized[i:])
+		if strings.ContainsRune(ignoredGeneralIPA, r) {
+			i += size
+			continue
+		}
+		if unicode.IsSpace(r) {
+			if len(res) > 0 {
+				from, to := inputSpan(segments, i, i+size)
+				words = append(words, res)
+				gaps = append(gaps, Approximation{From: trans[from:to], ByteOffset: from, RuneOffset: utf8.RuneCountInString(trans[:from]),
+					Message: "no word delimiter in the symbol set, word boundary dropped"})
+				res = nil
+			}
+			i += size
+			continue
+		}
+		n := ss.generalIPASegment(normalized[i:])
+		seg := normalized[i : i+n]
+		if parts, ok := ss.splitIPASegment(seg); ok {
+			res = append(res, parts...)
+			i += n
+			continue
+		}
+		if i+n < len(normalized) {
+			// segments written without a tie bar (e.g., diphthongs)
+			n2 := ss.generalIPASegment(normalized[i+n:])
+			if tied, ok := ss.tiedSymbol(seg, normalized[i+n:i+n+n2]); ok {
+				seg, n = normalized[i:i+n+n2], n+n2
+				from, to := inputSpan(segments, i, i+n)
+				approximations = append(approximations, Approximation{From: trans[from:to], To: tied, ByteOffset: from, RuneOffset: utf8.RuneCountInString(trans[:from]),
+					Message: fmt.Sprintf("/%s/ approximated by /%s/ (tie bar added)", seg, tied)})
+				res = append(res, tied)
+				i += n
+				continue
+			}
+		}
+
+		from, to := inputSpan(segments, i, i+n)
+		a := Approximation{From: trans[from:to], ByteOffset: from, RuneOffset: utf8.RuneCountInString(trans[:from])}
+		mapped := ss.approximateGeneralIPA(seg)
+		if len(mapped) == 0 {
+			a.Message = fmt.Sprintf("no symbol for /%s/ (%s), dropped", seg, DescribeIPA(seg))
+		} else {
+			res = append(res, mapped...)
+			a.To = strings.Join(mapped, ss.PhonemeDelimiter.IPA.String)
+			a.Message = fmt.Sprintf("/%s/ (%s) approximated by /%s/ (%s)", seg, DescribeIPA(seg), a.To, DescribeIPA(strings.Join(mapped, "")))
+		}
+		approximations = append(approximations, a)
+		i += n
+	}
+	if len(res) > 0 {
+		words = append(words, res)
+	}
+	if len(words) == 0 {
+		return "", approximations, nil
+	}
+
+	var converted []string
+	for _, word := range words {
+		c, err := ss.ConvertFromInternalIPA(strings.Join(word, ss.PhonemeDelimiter.IPA.String))
+		if err != nil {
+			return "", approximations, err
+		}
+		converted = append(converted, c)
+	}
+	delimiter := ss.PhonemeDelimiter.String
+	if wd, ok := ss.wordDelimiter(); ok {
+		delimiter = strings.Join([]string{ss.PhonemeDelimiter.String, wd.String, ss.PhonemeDelimiter.String}, "")
+	} else if len(words) > 1 {
+		approximations = append(approximations, gaps[:len(words)-1]...)
+		sort.SliceStable(approximations, func(i, j int) bool { return approximations[i].ByteOffset < approximations[j].ByteOffset })
+	}
+	return strings.Join(converted, delimiter), approximations, nil
+}
+
+// wordDelimiter returns the first word delimiter in the symbol set, if any
+func (ss SymbolSet) wordDelimiter() (Symbol, bool) {
+	for _, sym := range filterSymbolsByCat(ss.Symbols, []SymbolCat{WordDelimiter}) {
+		if len(sym.String) > 0 {
+			return sym, true
+		}
+	}
+	return Symbol{}, false
+}
+
+// splitIPASegment splits an IPA segment into symbols in the inventory (e.g., for symbol sets with separate symbols for diacritics), if possible
+func (ss SymbolSet) splitIPASegment(seg string) ([]string, bool) {
+	if sym, err := ss.GetFromInternalIPA(seg); err == nil {
+		return []string{sym.IPA.String}, true
+	}
+	parts, unknown := ss.ipaTrie.split(seg)
+	if len(unknown) > 0 || len(parts) == 0 {
+		return nil, false
+	}
+	var res []string
+	for _, p := range parts {
+		sym, err := ss.GetFromInternalIPA(p)
+		if err != nil {
+			return nil, false
+		}
+		res = append(res, sym.IPA.String)
+	}
+	return res, true
+}
+
+// tiedSymbol returns the IPA symbol consisting of the two segments joined by a tie bar, if there is one in the inventory
+func (ss SymbolSet) tiedSymbol(seg1, seg2 string) (string, bool) {
+	for _, tie := range ipaEquivalents[0] {
+		if sym, err := ss.GetFromInternalIPA(seg1 + string(tie) + seg2); err == nil {
+			return sym.IPA.String, true
+		}
+	}
+	return "", false
+}
+
+// approximateGeneralIPA returns the closest IPA symbol(s) in the inventory for an IPA segment, or an empty slice if there is no close symbol
+func (ss SymbolSet) approximateGeneralIPA(seg string) []string {
+	if parts, ok := ss.dropModifiers(seg); ok {
+		return parts
+	}
+
+	// tied segments (e.g., affricates) are split into parts
+	runes := []rune(seg)
+	for i, r := range runes {
+		if isTieBar(r) && i > 0 && i < len(runes)-1 {
+			var res []string
+			for _, part := range []string{string(runes[:i]), string(runes[i+1:])} {
+				if parts, ok := ss.splitIPASegment(part); ok {
+					res = append(res, parts...)
+				} else {
+					res = append(res, ss.approximateGeneralIPA(part)...)
+				}
+			}
+			return res
+		}
+	}
+
+	if sym, ok := ss.nearestSymbol(seg); ok {
+		return []string{sym}
+	}
+	return []string{}
+}
+
+// dropModifiers returns the IPA symbol(s) in the inventory that are obtained by dropping as few modifiers as possible from the segment, if any
+func (ss SymbolSet) dropModifiers(seg string) ([]string, bool) {
+	runes := []rune(seg)
+	var mods []int
+	for i, r := range runes {
+		if i > 0 && isModifier(r) {
+			mods = append(mods, i)
+		}
+	}
+	if len(mods) == 0 || len(mods) > maxDroppedModifiers {
+		return nil, false
+	}
+	// test all combinations of dropped modifiers, fewest dropped first
+	for nDropped := 1; nDropped <= len(mods); nDropped++ {
+		for mask := 1; mask < 1<<len(mods); mask++ {
+			if bitCount(mask) != nDropped {
+				continue
+			}
+			dropped := make(map[int]bool)
+			for j, i := range mods {
+				if mask&(1<<j) != 0 {
+					dropped[i] = true
+				}
+			}
+			var s strings.Builder
+			for i, r := range runes {
+				if !dropped[i] {
+					s.WriteRune(r)
+				}
+			}
+			if parts, ok := ss.splitIPASegment(s.String()); ok {
+				return parts, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func bitCount(n int) int {
+	res := 0
+	for ; n > 0; n >>= 1 {
+		res += n & 1
+	}
+	return res
+}
+
+// nearestSymbol returns the phonetically closest phoneme in the inventory, if any (see phoneticDistance).
+// Vowels are always mapped to the closest vowel (if any), so that syllables keep their nucleus. Consonants are only mapped to consonants within maxPhoneticDistance.
+func (ss SymbolSet) nearestSymbol(seg string) (string, bool) {
+	f, ok := features(seg)
+	if !ok {
+		return "", false
+	}
+	best, bestDist, bestRank := "", 0, 0
+	for _, sym := range ss.PhoneticSymbols {
+		dist, ok := phoneticDistance(seg, sym.IPA.String)
+		if !ok || (!f.vowel && dist > maxPhoneticDistance) {
+			continue
+		}
+		// on equal distance, prefer the symbol with the most similar diacritics
+		rank := 4*dist + modifierDistance(seg, sym.IPA.String)
+		if best == "" || rank < bestRank {
+			best, bestDist, bestRank = sym.IPA.String, dist, rank
+		}
+	}
+	return best, best != "" && (f.vowel || bestDist <= maxPhoneticDistance)
+}
+
+// phoneticFeatures are the phonetic properties of an IPA base character, as parsed from the description in the IPA character database
+type phoneticFeatures struct {
+	vowel     bool
+	voiceless bool
+	lateral   bool
+	rhotic    bool
+	place     int // consonant place of articulation, or vowel backness
+	height    int // vowel height
+	manner    string
+	rounding  string
+}
+
+var placeScale = map[string]int{
+	"bilabial": 0, "labiodental": 1, "dental": 2, "alveolar": 3, "postalveolar": 4, "retroflex": 5, "alveolo-palatal": 5,
+	"palatal": 6, "labial-palatal": 6, "velar": 7, "labiovelar": 7, "uvular": 8, "pharyngeal": 9, "epiglottal": 10, "glottal": 11,
+}
+
+var heightScale = map[string]int{
+	"close": 0, "near-close": 1, "close-mid": 2, "mid": 3, "open-mid": 4, "near-open": 5, "raised-open": 5, "open": 6,
+}
+
+var backnessScale = map[string]int{
+	"front": 0, "near-front": 1, "central": 2, "near-back": 3, "back": 4,
+}
+
+var mannerNames = []string{"plosive", "stop", "implosive", "affricate", "fricative", "nasal", "trill", "tap", "flap", "approximant", "click"}
+
+// closeManners are pairs of manners of articulation that are closer to each other than to the others
+var closeManners = map[[2]string]int{
+	{"plosive", "implosive"}:     1,
+	{"plosive", "affricate"}:     1,
+	{"affricate", "fricative"}:   1,
+	{"tap", "trill"}:             1,
+	{"plosive", "tap"}:           2,
+	{"fricative", "approximant"}: 2,
+	{"trill", "approximant"}:     2,
+}
+
+func mannerDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if d, ok := closeManners[[2]string{a, b}]; ok {
+		return d
+	}
+	if d, ok := closeManners[[2]string{b, a}]; ok {
+		return d
+	}
+	return 4
+}
+
+// features returns the phonetic features for the first character of the IPA string, if it is a vowel or a consonant in the IPA character database
+func features(s string) (phoneticFeatures, bool) {
+	r, _ := utf8.DecodeRuneInString(s)
+	c, ok := ipa.Lookup(r)
+	if !ok || (c.Class != ipa.Vowel && c.Class != ipa.Consonant) {
+		return phoneticFeatures{}, false
+	}
+	res := phoneticFeatures{vowel: c.Class == ipa.Vowel, voiceless: c.Voicing == "voiceless", place: -1, height: -1}
+	for _, w := range strings.Fields(strings.ToLower(c.Desc)) {
+		switch {
+		case w == "lateral":
+			res.lateral = true
+		case w == "rhotacized":
+			res.rhotic = true
+		case w == "rounded" || w == "unrounded":
+			res.rounding = w
+		case res.vowel:
+			if h, ok := heightScale[w]; ok {
+				res.height = h
+			} else if b, ok := backnessScale[w]; ok {
+				res.place = b
+			}
+		default:
+			if p, ok := placeScale[w]; ok {
+				res.place = p
+			}
+			for _, m := range mannerNames {
+				if w == m {
+					res.manner = m
+				}
+			}
+		}
+	}
+	if res.manner == "stop" {
+		res.manner = "plosive"
+	} else if res.manner == "flap" {
+		res.manner = "tap"
+	}
+	return res, true
+}
+
+// phoneticDistance compares the base characters of two IPA strings (vowels with vowels, and consonants with consonants), using height, backness and rounding for vowels,
+// and manner, place and voicing for consonants.
+func phoneticDistance(a, b string) (int, bool) {
+	fa, okA := features(a)
+	fb, okB := features(b)
+	if !okA || !okB || fa.vowel != fb.vowel {
+		return 0, false
+	}
+	dist := 0
+	scaleDist := func(x, y int) int {
+		if x < 0 || y < 0 {
+			return 1
+		}
+		return abs(x - y)
+	}
+	if fa.vowel {
+		dist += scaleDist(fa.height, fb.height) + scaleDist(fa.place, fb.place)
+		if fa.rounding != "" && fb.rounding != "" && fa.rounding != fb.rounding {
+			dist += 2
+		}
+		if fa.rhotic != fb.rhotic {
+			dist += 2
+		}
+	} else {
+		dist += mannerDistance(fa.manner, fb.manner)
+		if fa.lateral != fb.lateral {
+			dist += 2
+		}
+		dist += scaleDist(fa.place, fb.place)
+		if fa.voiceless != fb.voiceless {
+			dist++
+		}
+	}
+	return dist, true
+}
+
+// modifierDistance compares the characters following the base characters of two IPA strings: characters only in b count double, since they add something that is not in the input
+func modifierDistance(a, b string) int {
+	ra, rb := []rune(a)[1:], []rune(b)[1:]
+	dist := 0
+	for _, r := range rb {
+		if !strings.ContainsRune(string(ra), r) {
+			dist += 2
+		}
+	}
+	for _, r := range ra {
+		if !strings.ContainsRune(string(rb), r) {
+			dist++
+		}
+	}
+	return dist
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,145 @@
+package symbolset
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ConvertFromGeneralIPA(t *testing.T) {
+	sv, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	cmu, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+
+	var tests = []struct {
+		ss             SymbolSet
+		input          string
+		expect         string
+		approximations string // From:To, separated by |
+	}{
+		// exact
+		{sv, "/ˈhɛːst/", `" h E: s t`, ""},
+		{cmu, "ˈθɪŋk", "TH IH1 NG K", ""},
+
+		// dropped diacritics
+		{sv, "[ˈtʰɑːk]", `" t A: k`, "tʰ:t"},
+		{sv, "ˈkɑ̝ːl", `" k A: l`, "ɑ̝ː:ɑː"},
+		{cmu, "/ˈhɛːst/", "HH EH1 S T", "ɛː:ɛ"},
+
+		// nearest symbol
+		{sv, "ˈθɪŋk", `" f I N k`, "θ:f"},
+		{sv, "ˈɐbɔ", `" { b O`, "ɐ:æ"},
+		{cmu, "[ˈt͡ʃɪɾi]", "CH IH1 R IY", "ɾ:r"},
+
+		// tie bars
+		{sv, "ˌɛnt͡s", "% E n t s", "t͡s:ts"},
+		{cmu, "/həˈloʊ/", "HH AX L OW1", "oʊ:o⁀ʊ"},
+
+		// dropped
+		{sv, "ˈʔa", `" a`, "ʔ:"},
+
+		// words (no word delimiter in the symbol sets)
+		{sv, "ˈhɛːst ˈhɛːst", `" h E: s t " h E: s t`, " :"},
+		{cmu, "/ˈθɪŋk  ˈθɪŋk/", "TH IH1 NG K TH IH1 NG K", " :"},
+	}
+	for _, test := range tests {
+		result, approximations, err := test.ss.ConvertFromGeneralIPA(test.input)
+		if err != nil {
+			t.Errorf("%s /%s/: didn't expect error here : %v", test.ss.Name, test.input, err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("%s /%s/: "+fsExp, test.ss.Name, test.input, test.expect, result)
+		}
+		var as []string
+		for _, a := range approximations {
+			as = append(as, a.From+":"+a.To)
+		}
+		if got := strings.Join(as, "|"); got != test.approximations {
+			t.Errorf("%s /%s/: "+fsExp, test.ss.Name, test.input, test.approximations, got)
+		}
+	}
+}
+
+func Test_ConvertFromGeneralIPA_Positions(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	_, approximations, err := ss.ConvertFromGeneralIPA("[ˈtʰɑːk]")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if len(approximations) != 1 {
+		t.Fatalf("expected 1 approximation, found %v", approximations)
+	}
+	a := approximations[0]
+	if a.ByteOffset != 3 || a.RuneOffset != 2 || !strings.Contains(a.Message, "aspirated") {
+		t.Errorf("unexpected approximation %#v", a)
+	}
+}
+
+func Test_ConvertFromGeneralIPA_WordDelimiter(t *testing.T) {
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "t", Cat: NonSyllabic, IPA: IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: " ", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "#", Cat: WordDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	ss, err := NewSymbolSet("test", symbols)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{"ta at", "t a # a t"},
+		{" [ta]  [at] ", "t a # a t"},
+		{"ta\tat ta", "t a # a t # t a"},
+	}
+	for _, test := range tests {
+		result, approximations, err := ss.ConvertFromGeneralIPA(test.input)
+		if err != nil {
+			t.Errorf("/%s/: didn't expect error here : %v", test.input, err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("/%s/: "+fsExp, test.input, test.expect, result)
+		}
+		if len(approximations) != 0 {
+			t.Errorf("/%s/: expected no approximations, found %v", test.input, approximations)
+		}
+	}
+}
+
+func Test_PhoneticDistance(t *testing.T) {
+	var tests = []struct {
+		a, b   string
+		expect int
+	}{
+		{"t", "t", 0},
+		{"t", "d", 1},
+		{"θ", "f", 1},
+		{"ɾ", "r", 1},
+		{"i", "y", 2},
+		{"i", "ɪ", 2},
+	}
+	for _, test := range tests {
+		dist, ok := phoneticDistance(test.a, test.b)
+		if !ok {
+			t.Errorf("/%s/ /%s/: expected distance", test.a, test.b)
+			continue
+		}
+		if dist != test.expect {
+			t.Errorf("/%s/ /%s/: "+fsExp, test.a, test.b, test.expect, dist)
+		}
+	}
+	if _, ok := phoneticDistance("t", "a"); ok {
+		t.Errorf("expected no distance between consonants and vowels")
+	}
+}
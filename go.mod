@@ -1,8 +1,9 @@
 module github.com/stts-se/symbolset
 
-go 1.24
+go 1.24.0
 
 require (
 	github.com/dlclark/regexp2 v1.11.5
 	github.com/gorilla/mux v1.8.1
+	golang.org/x/text v0.34.0
 )
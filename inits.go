@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -44,6 +46,16 @@ func symbolCatFromString(s string) (SymbolCat, error) {
 		symCat = CompoundDelimiter
 	case "WordDelimiter":
 		symCat = WordDelimiter
+	case "Tone":
+		symCat = Tone
+	case "Length":
+		symCat = Length
+	case "Diacritic":
+		symCat = Diacritic
+	case "Pause":
+		symCat = Pause
+	case "IntonationBoundary":
+		symCat = IntonationBoundary
 	default:
 		return symCat, UnknownSymbolType([]string{s})
 	}
@@ -55,17 +67,82 @@ func NewSymbolSet(name string, symbols []Symbol) (SymbolSet, error) {
 	return NewSymbolSetWithTests(name, symbols, []string{}, true)
 }
 
-// NewSymbolSetWithTests is a constructor for 'symbols' with built-in error checks
+// NewSymbolSetWithTests is a constructor for 'symbols' with built-in error checks.
+// The checkForDups parameter is ignored, and only kept for compatibility: duplicate symbols are accepted, and reported by Lint (or rejected when loading the symbol set using LoadSymbolSetStrict).
 func NewSymbolSetWithTests(name string, symbols []Symbol, testLines []string, checkForDups bool) (SymbolSet, error) {
+	return NewSymbolSetWithOptions(name, symbols, SymbolSetOptions{TestLines: testLines})
+}
+
+// SymbolSetOptions are the optional parts of a symbol set, used by NewSymbolSetWithOptions
+type SymbolSetOptions struct {
+	// Aliases are alternative symbols for the symbols in the symbol set (see Alias)
+	Aliases []Alias
+
+	// Modifiers are combined with the base symbols into composed symbols (see Modifier)
+	Modifiers []Modifier
+
+	// TestLines are the symbol set tests, in .sym format (TEST lines), that are run when the symbol set is created
+	TestLines []string
+
+	// Normalization is the Unicode normalization form for IPA strings. The IPA strings of the symbols, aliases and modifiers are normalized to this form, after the IPA UNICODE values have been checked.
+	Normalization NormalizationForm
+
+	// StressPlacement is the declared stress placement convention (see SymbolSet.StressPlacement)
+	StressPlacement StressPlacement
+
+	// DisabledRules are the well-formedness rules that should not be checked by CheckWellFormedness
+	DisabledRules []Rule
+
+	// Metadata are the metadata directives of the symbol set (TYPE, LANGUAGE, VERSION, DESCRIPTION, LICENSE and SOURCE), by directive name
+	Metadata map[string]string
+}
+
+// NewSymbolSetWithOptions is a constructor for 'symbols' with built-in error checks, using the specified options.
+// The base symbols are combined with the modifiers into composed symbols (see Modifier), that are valid symbols in the symbol set, but not included in Symbols.
+func NewSymbolSetWithOptions(name string, symbols []Symbol, opts SymbolSetOptions) (SymbolSet, error) {
 	var nilRes SymbolSet
 
+	// compare ipa string vs unicode
+	for _, symbol := range symbols {
+		uFromString := string2unicode(symbol.IPA.String)
+
+		if len(symbol.IPA.String) == 0 {
+			uFromString = ""
+		}
+		if symbol.IPA.Unicode != uFromString {
+			if fromUnicode, err := unicode2string(symbol.IPA.Unicode); err == nil && fromUnicode != "" {
+				return nilRes, fmt.Errorf("ipa symbol /%s/ (%s) does not match unicode '%s' (/%s/: %s) -- expected '%s'", symbol.IPA.String, DescribeIPA(symbol.IPA.String), symbol.IPA.Unicode, fromUnicode, DescribeIPA(fromUnicode), uFromString)
+			}
+			return nilRes, fmt.Errorf("ipa symbol /%s/ does not match unicode '%s' -- expected '%s'", symbol.IPA.String, symbol.IPA.Unicode, uFromString)
+		}
+		if len(symbol.IPA.String) > 1 && strings.Contains(symbol.IPA.String, " ") {
+			return nilRes, fmt.Errorf("ipa symbols cannot contain white space -- found /%s/", symbol.IPA.String)
+		}
+	}
+
+	form := opts.Normalization
+	testLines := opts.TestLines
+	symbols = normalizeSymbols(symbols, form)
+	aliases := normalizeAliases(opts.Aliases, form)
+
+	if err := checkModifiers(opts.Modifiers); err != nil {
+		return nilRes, fmt.Errorf("invalid modifier in symbol set %s : %w", name, err)
+	}
+	modifiers := normalizeModifiers(opts.Modifiers, form)
+	composed, err := composeSymbols(symbols, modifiers, form)
+	if err != nil {
+		return nilRes, fmt.Errorf("invalid modifiers in symbol set %s : %w", name, err)
+	}
+	// all symbols, including the composed ones (explicit symbols first, so that they take precedence)
+	all := append(symbols[:len(symbols):len(symbols)], composed...)
+
 	// filtered lists
-	phonemes := filterSymbolsByCat(symbols, []SymbolCat{Syllabic, NonSyllabic, Stress})
-	phoneticSymbols := filterSymbolsByCat(symbols, []SymbolCat{Syllabic, NonSyllabic})
-	stressSymbols := filterSymbolsByCat(symbols, []SymbolCat{Stress})
-	syllabic := filterSymbolsByCat(symbols, []SymbolCat{Syllabic})
-	nonSyllabic := filterSymbolsByCat(symbols, []SymbolCat{NonSyllabic})
-	phonemeDelimiters := filterSymbolsByCat(symbols, []SymbolCat{PhonemeDelimiter})
+	phonemes := filterSymbolsByCat(all, []SymbolCat{Syllabic, NonSyllabic, Stress})
+	phoneticSymbols := filterSymbolsByCat(all, []SymbolCat{Syllabic, NonSyllabic})
+	stressSymbols := filterSymbolsByCat(all, []SymbolCat{Stress})
+	syllabic := filterSymbolsByCat(all, []SymbolCat{Syllabic})
+	nonSyllabic := filterSymbolsByCat(all, []SymbolCat{NonSyllabic})
+	phonemeDelimiters := filterSymbolsByCat(all, []SymbolCat{PhonemeDelimiter})
 
 	// specific symbol initialization
 	if len(phonemeDelimiters) < 1 {
@@ -90,7 +167,7 @@ func NewSymbolSetWithTests(name string, symbols []Symbol, testLines []string, ch
 	if err != nil {
 		return nilRes, err
 	}
-	symbolRe, err := buildRegexpWithGroup(symbols, true, false)
+	symbolRe, err := buildRegexpWithGroup(all, true, false)
 
 	if err != nil {
 		return nilRes, err
@@ -114,38 +191,46 @@ func NewSymbolSetWithTests(name string, symbols []Symbol, testLines []string, ch
 		return nilRes, err
 	}
 
-	// compare ipa string vs unicode
-	for _, symbol := range symbols {
-		uFromString := string2unicode(symbol.IPA.String)
+	repeatedPhonemeDelimiters, err := regexp.Compile(phonemeDelimiterRe.String() + "+")
+	if err != nil {
+		return nilRes, err
+	}
 
-		if len(symbol.IPA.String) == 0 {
-			uFromString = ""
-		}
-		if symbol.IPA.Unicode != uFromString {
-			return nilRes, fmt.Errorf("ipa symbol /%s/ does not match unicode '%s' -- expected '%s'", symbol.IPA.String, symbol.IPA.Unicode, uFromString)
+	symbolAliases, ipaAliases, err := buildAliasMaps(all, aliases)
+	if err != nil {
+		return nilRes, fmt.Errorf("invalid alias in symbol set %s : %w", name, err)
+	}
+
+	// lookup indexes (if a symbol string or IPA string is defined more than once, the first one is used)
+	symbolIndex := make(map[string]int, len(all))
+	ipaIndex := make(map[string]int, len(all))
+	for i, symbol := range all {
+		if _, exists := symbolIndex[symbol.String]; !exists {
+			symbolIndex[symbol.String] = i
 		}
-		if len(symbol.IPA.String) > 1 && strings.Contains(symbol.IPA.String, " ") {
-			return nilRes, fmt.Errorf("ipa symbols cannot contain white space -- found /%s/", symbol.IPA.String)
+		if _, exists := ipaIndex[symbol.IPA.String]; !exists {
+			ipaIndex[symbol.IPA.String] = i
 		}
 	}
 
-	if checkForDups {
-		seenSymbols := make(map[string]Symbol)
-		var dupSymbols []string
-		for _, symbol := range symbols {
-			if _, exists := seenSymbols[symbol.String]; exists {
-				dupSymbols = append(dupSymbols, symbol.String)
-			}
-			seenSymbols[symbol.String] = symbol
+	// tries for splitting transcriptions without phoneme delimiters
+	var symbolStrings, ipaStrings []string
+	for _, symbol := range all {
+		symbolStrings = append(symbolStrings, symbol.String)
+		ipaStrings = append(ipaStrings, symbol.IPA.String)
+	}
+	for _, a := range aliases {
+		if a.IPA {
+			ipaStrings = append(ipaStrings, a.Alias)
+		} else {
+			symbolStrings = append(symbolStrings, a.Alias)
 		}
-		// if len(dupSymbols) > 0 {
-		// 	return nilRes, fmt.Errorf("input symbol set contains duplicates of phoneme %v. All symbols: %v", dupSymbols, symbols)
-		// }
 	}
 
-	repeatedPhonemeDelimiters, err := regexp.Compile(phonemeDelimiterRe.String() + "+")
-	if err != nil {
-		return nilRes, err
+	// ambiguous segmentations can only occur if there is no phoneme delimiter
+	var ambiguities []Ambiguity
+	if phonemeDelimiterRe.FindStringIndex("") != nil {
+		ambiguities = findAmbiguities(symbolStrings)
 	}
 
 	ssType := symbolSetTypeFromString(name)
@@ -174,8 +259,39 @@ func NewSymbolSetWithTests(name string, symbols []Symbol, testLines []string, ch
 		ipaPhonemeRe:     ipaPhonemeRe,
 
 		PhonemeDelimiter:          phonemeDelimiter,
+		phonemeDelimiters:         phonemeDelimiters,
 		phonemeDelimiterRe:        phonemeDelimiterRe,
 		repeatedPhonemeDelimiters: repeatedPhonemeDelimiters,
+
+		symbolIndex: symbolIndex,
+		ipaIndex:    ipaIndex,
+
+		symbolTrie: newTrie(symbolStrings),
+		ipaTrie:    newTrie(ipaStrings),
+
+		ambiguities: ambiguities,
+
+		Aliases:       aliases,
+		symbolAliases: symbolAliases,
+		ipaAliases:    ipaAliases,
+
+		Modifiers: modifiers,
+		composed:  composed,
+
+		testLines: testLines,
+
+		NormalizationForm: form,
+		ipaEquivalents:    buildEquivalentsMap(all, aliases),
+
+		StressPlacement: opts.StressPlacement,
+		DisabledRules:   opts.DisabledRules,
+	}
+	if err := applyMetadata(&res, opts.Metadata); err != nil {
+		return nilRes, fmt.Errorf("couldn't load metadata in symbol set %s : %w", name, err)
+	}
+	res.filters, err = buildFilters(res)
+	if err != nil {
+		return nilRes, fmt.Errorf("couldn't build filters for symbol set %s : %w", res.Name, err)
 	}
 	testRes, err := testSymbolSet(res, testLines)
 	if err != nil {
@@ -198,41 +314,134 @@ func LoadSymbolSet(fName string) (SymbolSet, error) {
 
 var header = "DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY"
 
+// headerWithFeatures is used for symbol set files with the optional feature column
+var headerWithFeatures = header + "	FEATURES"
+
 // LoadSymbolSetWithName loads a SymbolSet from file, and names the SymbolSet
 func LoadSymbolSetWithName(name string, fName string) (SymbolSet, error) {
-	var nilRes SymbolSet
 	fh, err := os.Open(filepath.Clean(fName))
 	if err != nil {
-		return nilRes, err
+		return SymbolSet{}, err
+	}
+	/* #nosec G307 */
+	defer fh.Close()
+	ss, err := ReadSymbolSet(name, fh)
+	if err != nil {
+		return SymbolSet{}, fmt.Errorf("couldn't load symbol set from file %v : %w", fName, err)
+	}
+	return ss, nil
+}
+
+// LoadSymbolSetFS loads a SymbolSet from a file in the specified file system (e.g., embed.FS or fstest.MapFS)
+func LoadSymbolSetFS(fsys fs.FS, fName string) (SymbolSet, error) {
+	name := path.Base(fName)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	fh, err := fsys.Open(fName)
+	if err != nil {
+		return SymbolSet{}, err
 	}
 	/* #nosec G307 */
 	defer fh.Close()
-	s := bufio.NewScanner(fh)
+	ss, err := ReadSymbolSet(name, fh)
+	if err != nil {
+		return SymbolSet{}, fmt.Errorf("couldn't load symbol set from file %v : %w", fName, err)
+	}
+	return ss, nil
+}
+
+// ReadSymbolSet reads a SymbolSet in .sym format from the reader, and names the SymbolSet
+func ReadSymbolSet(name string, r io.Reader) (SymbolSet, error) {
+	var nilRes SymbolSet
+	var err error
+	s := bufio.NewScanner(r)
 	n := 0
 	var descIndex = 0
 	var symbolIndex = 1
 	var ipaIndex = 2
 	var ipaUnicodeIndex = 3
 	var symCatIndex = 4
+	var featuresIndex = 5
+	var hasFeatures = false
 	var symbols = make([]Symbol, 0)
 	var testLines = make([]string, 0)
+	var stressPlacement = StressUndefined
+	var normalizationForm = FormNone
+	var metadata = make(map[string]string)
+	var aliases = make([]Alias, 0)
+	var modifiers = make([]Modifier, 0)
+	var disabledRules []Rule
+	var layout []symLine
 	for s.Scan() {
 		if err := s.Err(); err != nil {
 			return nilRes, err
 		}
 		n++
 		l := s.Text()
-		if len(strings.TrimSpace(l)) > 0 && !strings.HasPrefix(strings.TrimSpace(l), "#") {
+		if len(strings.TrimSpace(l)) == 0 || strings.HasPrefix(strings.TrimSpace(l), "#") {
+			if n > 1 {
+				layout = append(layout, symLine{line: n, kind: commentLine, text: l})
+			}
+		} else {
 			if n == 1 { // header
-				if l != header {
-					return nilRes, fmt.Errorf("expected header '%s', found '%s'", header, l)
+				if l == headerWithFeatures {
+					hasFeatures = true
+				} else if l != header {
+					return nilRes, fmt.Errorf("expected header '%s' or '%s', found '%s'", header, headerWithFeatures, l)
 				}
 			} else if isTestLine(l) {
+				layout = append(layout, symLine{line: n, kind: testLine, index: len(testLines)})
 				testLines = append(testLines, l)
+			} else if isStressPlacementLine(l) {
+				layout = append(layout, symLine{line: n, kind: stressPlacementLine})
+				stressPlacement, err = parseStressPlacementLine(l)
+				if err != nil {
+					return nilRes, fmt.Errorf("couldn't load stress placement in symbol set %s : %w", name, err)
+				}
+			} else if isNormalizationLine(l) {
+				layout = append(layout, symLine{line: n, kind: normalizationLine})
+				normalizationForm, err = parseNormalizationLine(l)
+				if err != nil {
+					return nilRes, fmt.Errorf("couldn't load normalization form in symbol set %s : %w", name, err)
+				}
+			} else if isDisableRuleLine(l) {
+				rule, err := parseDisableRuleLine(l)
+				if err != nil {
+					return nilRes, fmt.Errorf("couldn't load rule in symbol set %s : %w", name, err)
+				}
+				layout = append(layout, symLine{line: n, kind: disableRuleLine, index: len(disabledRules)})
+				disabledRules = append(disabledRules, rule)
+			} else if isModifierLine(l) {
+				modifier, err := parseModifierLine(l)
+				if err != nil {
+					return nilRes, fmt.Errorf("couldn't load modifier in symbol set %s : %w", name, err)
+				}
+				layout = append(layout, symLine{line: n, kind: modifierLine, index: len(modifiers)})
+				modifiers = append(modifiers, modifier)
+			} else if isAliasLine(l) {
+				alias, err := parseAliasLine(l)
+				if err != nil {
+					return nilRes, fmt.Errorf("couldn't load alias in symbol set %s : %w", name, err)
+				}
+				layout = append(layout, symLine{line: n, kind: aliasLine, index: len(aliases)})
+				aliases = append(aliases, alias)
+			} else if isMetadataLine(l) {
+				key, value, err := parseMetadataLine(l)
+				if err != nil {
+					return nilRes, fmt.Errorf("couldn't load metadata in symbol set %s : %w", name, err)
+				}
+				if _, exists := metadata[key]; exists {
+					return nilRes, fmt.Errorf("metadata %s is defined more than once in symbol set %s", key, name)
+				}
+				metadata[key] = value
+				layout = append(layout, symLine{line: n, kind: metadataLine, text: key})
 			} else {
 				fs := strings.Split(l, "\t")
-				if len(fs) != 5 {
-					return nilRes, fmt.Errorf("invalid input line in %s (expected %d fields, found %d) : %s", fName, 5, len(fs), l)
+				if len(fs) != 5 && !(hasFeatures && len(fs) == 6) {
+					expected := "5"
+					if hasFeatures {
+						expected = "5 or 6"
+					}
+					return nilRes, fmt.Errorf("invalid input line in symbol set %s (expected %s fields, found %d) : %s", name, expected, len(fs), l)
 				}
 				symbol := trimIfNeeded(fs[symbolIndex])
 				ipa := trimIfNeeded(fs[ipaIndex])
@@ -240,39 +449,62 @@ func LoadSymbolSetWithName(name string, fName string) (SymbolSet, error) {
 				desc := fs[descIndex]
 				symCat, err := symbolCatFromString(fs[symCatIndex])
 				if err != nil {
-					return nilRes, fmt.Errorf("couldn't load symbol cat in file %s : %s", fName, err)
+					return nilRes, fmt.Errorf("couldn't load symbol cat in symbol set %s : %s", name, err)
+				}
+				var features Features
+				if len(fs) > featuresIndex {
+					features, err = ParseFeatures(fs[featuresIndex])
+					if err != nil {
+						return nilRes, fmt.Errorf("couldn't load features in symbol set %s : %w", name, err)
+					}
 				}
 				ipaSym := IPASymbol{String: ipa, Unicode: ipaUnicode}
 				sym := Symbol{
-					String: symbol,
-					Cat:    symCat,
-					Desc:   desc,
-					IPA:    ipaSym,
+					String:   symbol,
+					Cat:      symCat,
+					Desc:     desc,
+					IPA:      ipaSym,
+					Features: features,
 				}
+				layout = append(layout, symLine{line: n, kind: symbolLine, index: len(symbols)})
 				symbols = append(symbols, sym)
 			}
 		}
 	}
 
-	ss, err := NewSymbolSetWithTests(name, symbols, testLines, true)
+	ss, err := NewSymbolSetWithOptions(name, symbols, SymbolSetOptions{
+		Aliases:         aliases,
+		Modifiers:       modifiers,
+		TestLines:       testLines,
+		Normalization:   normalizationForm,
+		StressPlacement: stressPlacement,
+		DisabledRules:   disabledRules,
+		Metadata:        metadata,
+	})
 	if err != nil {
-		return nilRes, fmt.Errorf("couldn't load symbol set from file %v : %w", fName, err)
+		return nilRes, err
 	}
+	ss.layout = layout
 	return ss, nil
 }
 
 // LoadSymbolSetsFromDir loads a all symbol sets from the specified folder (all files with .sym extension)
 func LoadSymbolSetsFromDir(dirName string) (map[string]SymbolSet, error) {
+	return LoadSymbolSetsFromFS(os.DirFS(dirName), ".")
+}
+
+// LoadSymbolSetsFromFS loads all symbol sets from the specified folder in the file system (all files with .sym extension)
+func LoadSymbolSetsFromFS(fsys fs.FS, dirName string) (map[string]SymbolSet, error) {
 	// list files in symbol set dir
-	fileInfos, err := ioutil.ReadDir(dirName)
+	dirEntries, err := fs.ReadDir(fsys, dirName)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading symbol set dir : %w", err)
 	}
 	var fErrs error
 	var symSets []SymbolSet
-	for _, fi := range fileInfos {
-		if strings.HasSuffix(fi.Name(), SymbolSetSuffix) {
-			symset, err := LoadSymbolSet(filepath.Join(dirName, fi.Name()))
+	for _, fi := range dirEntries {
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), SymbolSetSuffix) {
+			symset, err := LoadSymbolSetFS(fsys, path.Join(dirName, fi.Name()))
 			if err != nil {
 				thisErr := fmt.Errorf("couldn't load symbol set from file %s : %w", fi.Name(), err)
 				if fErrs != nil {
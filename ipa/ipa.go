@@ -0,0 +1,262 @@
+/*
+Package ipa is a database of IPA characters, with the Unicode code point, Unicode name, phonetic description and IPA number for each character.
+
+The database is built from the table in ipa_table.txt, which is also served by the mapping server at /ipa_table. The table lists vowels and consonants, followed by sections for clicks, diacritics, suprasegmentals and tones. The table has one character (or character sequence) per line, with tab separated fields:
+
+	Code point(s)	Character	HTML entity	Unicode name	Phonetic description	IPA number
+
+Characters listed under the section headers # CLICKS, # DIACRITICS, # SUPRASEGMENTALS and # TONES are classified accordingly. Other characters are classified as vowels or consonants, depending on the description.
+
+The rows of the table are used as is. The descriptions of the ASCII consonants in the ADDON section do not specify voicing (e.g., "bilabial plosive" for b), so the voicing of these consonants is listed separately in the package (see Char.Voicing).
+*/
+package ipa
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Table is the IPA table in text format
+//
+//go:embed ipa_table.txt
+var Table string
+
+// Class is the phonetic class of an IPA character
+type Class int
+
+const (
+	// Unknown is used for characters that are not in the database
+	Unknown Class = iota
+
+	// Vowel is used for vowels
+	Vowel
+
+	// Consonant is used for consonants (including clicks)
+	Consonant
+
+	// Diacritic is used for diacritics, including the tie bar
+	Diacritic
+
+	// Suprasegmental is used for stress, length, tone and intonation marks, and boundaries
+	Suprasegmental
+)
+
+var classNames = []string{"Unknown", "Vowel", "Consonant", "Diacritic", "Suprasegmental"}
+
+func (c Class) String() string {
+	if c < 0 || int(c) >= len(classNames) {
+		return fmt.Sprintf("Class(%d)", c)
+	}
+	return classNames[c]
+}
+
+// Char is an IPA character, or a sequence of characters (e.g., long vowels)
+type Char struct {
+	String  string
+	Unicode string // the code points, e.g. U+0061 U+02D0
+	Name    string // the Unicode name
+	Desc    string // the phonetic description
+	Number  string // the IPA number, if any
+	Class   Class
+
+	// Voicing is "voiced" or "voiceless" for consonants with voicing in the description, or listed in addonVoicing. It is empty for other characters.
+	Voicing string
+}
+
+// description returns the phonetic description, with the voicing added if it is not already included
+func (c Char) description() string {
+	if c.Voicing != "" && !strings.Contains(strings.ToLower(c.Desc), "voice") {
+		return c.Voicing + " " + c.Desc
+	}
+	return c.Desc
+}
+
+// addonVoicing is the voicing of the consonants in the ADDON section of the table, since the descriptions in the table do not specify it
+var addonVoicing = map[string]string{
+	"b": "voiced", "c": "voiceless", "d": "voiced", "f": "voiceless", "g": "voiced", "h": "voiceless", "k": "voiceless",
+	"p": "voiceless", "q": "voiceless", "s": "voiceless", "t": "voiceless", "v": "voiced", "x": "voiceless", "z": "voiced",
+	"ç": "voiceless", "ð": "voiced", "ħ": "voiceless", "β": "voiced", "θ": "voiceless", "χ": "voiceless",
+}
+
+// fallbackRanges are code point ranges for diacritics not listed in the IPA table
+var fallbackRanges = [][2]rune{
+	{0x02B0, 0x02FF}, // spacing modifier letters
+	{0x0300, 0x036F}, // combining diacritical marks
+	{0x1D00, 0x1DBF}, // phonetic extensions
+}
+
+var sectionClasses = map[string]Class{
+	"# CLICKS":          Consonant,
+	"# DIACRITICS":      Diacritic,
+	"# SUPRASEGMENTALS": Suprasegmental,
+	"# TONES":           Suprasegmental,
+}
+
+type database struct {
+	chars  []Char
+	byRune map[rune]int
+	byStr  map[string]int
+	// byDesc maps lower case descriptions to indices in chars
+	byDesc    map[string][]int
+	maxLength int
+}
+
+var db = parseTable(Table)
+
+func parseTable(table string) database {
+	res := database{
+		byRune: make(map[rune]int),
+		byStr:  make(map[string]int),
+		byDesc: make(map[string][]int),
+	}
+	section := Unknown
+	for _, l := range strings.Split(table, "\n") {
+		if strings.HasPrefix(l, "#") {
+			section = sectionClasses[strings.TrimSpace(l)]
+			continue
+		}
+		fs := strings.Split(l, "\t")
+		if len(fs) < 5 || !strings.HasPrefix(fs[0], "U+") {
+			continue
+		}
+		var s strings.Builder
+		for _, cp := range strings.Fields(fs[0]) {
+			var r rune
+			if _, err := fmt.Sscanf(cp, "U+%X", &r); err != nil {
+				continue
+			}
+			s.WriteRune(r)
+		}
+		c := Char{
+			String:  s.String(),
+			Unicode: fs[0],
+			Name:    strings.TrimSpace(fs[3]),
+			Desc:    strings.TrimSpace(fs[4]),
+			Class:   section,
+		}
+		if len(fs) > 5 {
+			c.Number = strings.TrimSpace(fs[5])
+		}
+		if c.Class == Unknown {
+			c.Class = Consonant
+			if strings.Contains(strings.ToLower(c.Desc), "vowel") {
+				c.Class = Vowel
+			}
+		}
+		if c.Class == Consonant {
+			for _, w := range strings.Fields(strings.ToLower(c.Desc)) {
+				if w == "voiced" || w == "voiceless" {
+					c.Voicing = w
+					break
+				}
+			}
+			if v, ok := addonVoicing[c.String]; ok && c.Voicing == "" {
+				c.Voicing = v
+			}
+		}
+		if _, exists := res.byStr[c.String]; exists {
+			continue
+		}
+		i := len(res.chars)
+		res.chars = append(res.chars, c)
+		res.byStr[c.String] = i
+		if r, n := utf8.DecodeRuneInString(c.String); n == len(c.String) {
+			res.byRune[r] = i
+		}
+		desc := strings.ToLower(c.Desc)
+		res.byDesc[desc] = append(res.byDesc[desc], i)
+		if d := strings.ToLower(c.description()); d != desc {
+			res.byDesc[d] = append(res.byDesc[d], i)
+		}
+		if n := utf8.RuneCountInString(c.String); n > res.maxLength {
+			res.maxLength = n
+		}
+	}
+	return res
+}
+
+// Lookup returns the IPA character for the rune
+func Lookup(r rune) (Char, bool) {
+	if i, ok := db.byRune[r]; ok {
+		return db.chars[i], true
+	}
+	return Char{}, false
+}
+
+// LookupString returns the IPA character for the string (a single character, or a sequence listed in the table, such as a long vowel)
+func LookupString(s string) (Char, bool) {
+	if i, ok := db.byStr[s]; ok {
+		return db.chars[i], true
+	}
+	return Char{}, false
+}
+
+// LookupDesc returns the IPA characters with the phonetic description (case is ignored). The description may include the voicing, also for characters where it is not included in the table (see Char.Voicing).
+func LookupDesc(desc string) []Char {
+	var res []Char
+	for _, i := range db.byDesc[strings.ToLower(strings.TrimSpace(desc))] {
+		res = append(res, db.chars[i])
+	}
+	return res
+}
+
+// Chars returns all characters in the database, sorted by code point(s)
+func Chars() []Char {
+	res := make([]Char, len(db.chars))
+	copy(res, db.chars)
+	sort.SliceStable(res, func(i, j int) bool { return res[i].String < res[j].String })
+	return res
+}
+
+// Classify returns the class of the rune. Diacritics and modifier letters not listed in the database are classified as Diacritic.
+func Classify(r rune) Class {
+	if c, ok := Lookup(r); ok {
+		return c.Class
+	}
+	for _, rng := range fallbackRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return Diacritic
+		}
+	}
+	return Unknown
+}
+
+// Describe returns a readable description of an IPA symbol string, e.g. "voiceless alveolar plosive, aspirated" for tʰ. The voicing of consonants is included (see Char.Voicing).
+// The string is split into characters using longest match against the database (so that long vowels, etc, are described as units).
+func Describe(s string) string {
+	var res []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		n := min(db.maxLength, len(runes)-i)
+		for ; n > 1; n-- {
+			if _, ok := LookupString(string(runes[i : i+n])); ok {
+				break
+			}
+		}
+		if c, ok := LookupString(string(runes[i : i+n])); ok {
+			res = append(res, lowerFirst(c.description()))
+		} else if Classify(runes[i]) == Diacritic {
+			res = append(res, fmt.Sprintf("unknown diacritic (U+%04X)", runes[i]))
+		} else {
+			res = append(res, fmt.Sprintf("unknown character '%c' (U+%04X)", runes[i], runes[i]))
+		}
+		i += n
+	}
+	return strings.Join(res, ", ")
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return s
+	}
+	// keep acronyms and names as is
+	if r2, _ := utf8.DecodeRuneInString(s[n:]); unicode.IsUpper(r2) {
+		return s
+	}
+	return string(unicode.ToLower(r)) + s[n:]
+}
@@ -0,0 +1,115 @@
+package ipa
+
+import "testing"
+
+func Test_Lookup(t *testing.T) {
+	for _, test := range []struct {
+		r      rune
+		desc   string
+		number string
+		class  Class
+	}{
+		{'ɑ', "Open back unrounded vowel", "305", Vowel},
+		{'ʃ', "Voiceless postalveolar fricative", "134", Consonant},
+		{'b', "bilabial plosive", "", Consonant},
+		{'ǃ', "Postalveolar click", "178", Consonant},
+		{'ʰ', "Aspirated", "404", Diacritic},
+		{'̃', "Nasalized", "424", Diacritic},
+		{'ˈ', "Primary stress", "501", Suprasegmental},
+		{'ː', "Long", "503", Suprasegmental},
+		{'˥', "Extra high tone letter", "", Suprasegmental},
+	} {
+		c, ok := Lookup(test.r)
+		if !ok {
+			t.Errorf("Lookup(%c) expected to find character", test.r)
+			continue
+		}
+		if c.Desc != test.desc || c.Number != test.number || c.Class != test.class {
+			t.Errorf("Lookup(%c) expected %s/%s/%s, got %s/%s/%s", test.r, test.desc, test.number, test.class, c.Desc, c.Number, c.Class)
+		}
+		if c.String != string(test.r) {
+			t.Errorf("Lookup(%c) expected string /%c/, got /%s/", test.r, test.r, c.String)
+		}
+	}
+	for r, exp := range map[rune]string{'b': "voiced", 't': "voiceless", 'ʃ': "voiceless", 'ɡ': "voiced", 'm': "", 'a': ""} {
+		if c, _ := Lookup(r); c.Voicing != exp {
+			t.Errorf("Lookup(%c) expected voicing '%s', got '%s'", r, exp, c.Voicing)
+		}
+	}
+	if _, ok := Lookup('A'); ok {
+		t.Errorf("Lookup(A) expected no character")
+	}
+}
+
+func Test_LookupString(t *testing.T) {
+	c, ok := LookupString("aː")
+	if !ok {
+		t.Fatalf("LookupString(aː) expected to find character")
+	}
+	if exp := "Open front unrounded vowel (long)"; c.Desc != exp {
+		t.Errorf("expected %s, got %s", exp, c.Desc)
+	}
+	if exp := "U+0061 U+02D0"; c.Unicode != exp {
+		t.Errorf("expected %s, got %s", exp, c.Unicode)
+	}
+}
+
+func Test_LookupDesc(t *testing.T) {
+	res := LookupDesc("voiced velar plosive")
+	if len(res) != 1 || res[0].String != "ɡ" {
+		t.Errorf("expected [ɡ], got %v", res)
+	}
+	res = LookupDesc("Tie bar")
+	if len(res) != 2 {
+		t.Errorf("expected 2 tie bars, got %v", res)
+	}
+	if res := LookupDesc("no such sound"); len(res) != 0 {
+		t.Errorf("expected no characters, got %v", res)
+	}
+}
+
+func Test_Classify(t *testing.T) {
+	for r, exp := range map[rune]Class{
+		'y': Vowel,
+		'ŋ': Consonant,
+		'̩': Diacritic,
+		'͆': Diacritic, // not in the table, but a combining mark
+		'ʽ': Diacritic, // not in the table, but a modifier letter
+		'‖': Suprasegmental,
+		'ꜜ': Suprasegmental,
+		'A': Unknown,
+		'3': Unknown,
+	} {
+		if got := Classify(r); got != exp {
+			t.Errorf("Classify(%c) expected %s, got %s", r, exp, got)
+		}
+	}
+}
+
+func Test_Describe(t *testing.T) {
+	for input, exp := range map[string]string{
+		"tʰ":  "voiceless alveolar plosive, aspirated",
+		"ɑː":  "open back unrounded vowel (long)",
+		"t⁀ʃ": "voiceless alveolar plosive, tie bar, voiceless postalveolar fricative",
+		"ˈɛ̃": "primary stress, open-mid front unrounded vowel, nasalized",
+		"aX":  "open front unrounded vowel, unknown character 'X' (U+0058)",
+		"a͆":  "open front unrounded vowel, unknown diacritic (U+0346)",
+		"":    "",
+	} {
+		if got := Describe(input); got != exp {
+			t.Errorf("Describe(%s) expected '%s', got '%s'", input, exp, got)
+		}
+	}
+}
+
+func Test_Chars(t *testing.T) {
+	chars := Chars()
+	if len(chars) < 200 {
+		t.Errorf("expected at least 200 characters, got %d", len(chars))
+	}
+	for i := 1; i < len(chars); i++ {
+		if chars[i-1].String >= chars[i].String {
+			t.Errorf("expected characters to be sorted and unique, found /%s/ before /%s/", chars[i-1].String, chars[i].String)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package symbolset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// JSON encoding of symbol sets
+
+// JSONSymbolSet : JSON container
+type JSONSymbolSet struct {
+	Name            string
+	Type            string
+	Language        string   `json:",omitempty"`
+	Version         string   `json:",omitempty"`
+	Description     string   `json:",omitempty"`
+	License         string   `json:",omitempty"`
+	Source          string   `json:",omitempty"`
+	StressPlacement string   `json:",omitempty"`
+	Normalization   string   `json:",omitempty"`
+	DisabledRules   []string `json:",omitempty"`
+	Symbols         []JSONSymbol
+	Aliases         []JSONAlias    `json:",omitempty"`
+	Modifiers       []JSONModifier `json:",omitempty"`
+	Tests           []JSONTest     `json:",omitempty"`
+}
+
+// JSONSymbol : JSON container
+type JSONSymbol struct {
+	Symbol   string
+	IPA      JSONIPA
+	Desc     string
+	Cat      string
+	Features []string `json:",omitempty"`
+}
+
+// JSONIPA : JSON container
+type JSONIPA struct {
+	String  string
+	Unicode string
+}
+
+// JSONAlias : JSON container for symbol aliases
+type JSONAlias struct {
+	Alias      string
+	Canonical  string
+	SymbolType string // SYMBOLS or IPA
+	Deprecated bool   `json:",omitempty"`
+}
+
+// JSONModifier : JSON container for modifier symbols
+type JSONModifier struct {
+	Symbol     string
+	IPA        string
+	Categories []string
+}
+
+// JSONTest : JSON container for symbol set tests
+type JSONTest struct {
+	Type       string // ACCEPT or REJECT
+	SymbolType string // SYMBOLS or IPA
+	Trans      string
+}
+
+// ToJSON converts the symbol set into a JSON container
+func (ss SymbolSet) ToJSON() JSONSymbolSet {
+	res := JSONSymbolSet{
+		Name:        ss.Name,
+		Type:        ss.Type.String(),
+		Language:    ss.Language,
+		Version:     ss.Version,
+		Description: ss.Description,
+		License:     ss.License,
+		Source:      ss.Source,
+		Symbols:     make([]JSONSymbol, 0),
+	}
+	if ss.StressPlacement != StressUndefined {
+		res.StressPlacement = ss.StressPlacement.String()
+	}
+	if ss.NormalizationForm != FormNone {
+		res.Normalization = ss.NormalizationForm.String()
+	}
+	for _, sym := range ss.Symbols {
+		res.Symbols = append(res.Symbols, JSONSymbol{
+			Symbol:   sym.String,
+			IPA:      JSONIPA{String: sym.IPA.String, Unicode: sym.IPA.Unicode},
+			Desc:     sym.Desc,
+			Cat:      sym.Cat.String(),
+			Features: sym.Features.List(),
+		})
+	}
+	for _, r := range ss.DisabledRules {
+		res.DisabledRules = append(res.DisabledRules, r.String())
+	}
+	for _, a := range ss.Aliases {
+		ja := JSONAlias{Alias: a.Alias, Canonical: a.Canonical, SymbolType: "SYMBOLS", Deprecated: a.Deprecated}
+		if a.IPA {
+			ja.SymbolType = "IPA"
+		}
+		res.Aliases = append(res.Aliases, ja)
+	}
+	for _, m := range ss.Modifiers {
+		jm := JSONModifier{Symbol: m.String, IPA: m.IPA}
+		for _, c := range m.Categories {
+			jm.Categories = append(jm.Categories, c.String())
+		}
+		res.Modifiers = append(res.Modifiers, jm)
+	}
+	for _, l := range ss.testLines {
+		// test lines have been validated when the symbol set was created
+		if t, err := parseSSTestLine(l); err == nil {
+			res.Tests = append(res.Tests, JSONTest{Type: t.testType, SymbolType: t.symbolType, Trans: t.trans})
+		}
+	}
+	return res
+}
+
+// NewSymbolSetFromJSON creates a new symbol set from a JSON container
+func NewSymbolSetFromJSON(jss JSONSymbolSet) (SymbolSet, error) {
+	var nilRes SymbolSet
+	var symbols = make([]Symbol, 0)
+	for _, js := range jss.Symbols {
+		cat, err := symbolCatFromString(js.Cat)
+		if err != nil {
+			return nilRes, fmt.Errorf("couldn't load symbol cat for symbol %s : %w", js.Symbol, err)
+		}
+		features, err := ParseFeatures(strings.Join(js.Features, ","))
+		if err != nil {
+			return nilRes, fmt.Errorf("couldn't load features for symbol %s : %w", js.Symbol, err)
+		}
+		symbols = append(symbols, Symbol{
+			String:   js.Symbol,
+			Cat:      cat,
+			Desc:     js.Desc,
+			IPA:      IPASymbol{String: js.IPA.String, Unicode: js.IPA.Unicode},
+			Features: features,
+		})
+	}
+	var aliases = make([]Alias, 0)
+	for _, ja := range jss.Aliases {
+		if ja.SymbolType != "SYMBOLS" && ja.SymbolType != "IPA" {
+			return nilRes, fmt.Errorf("invalid symbol type %s for alias %s", ja.SymbolType, ja.Alias)
+		}
+		aliases = append(aliases, Alias{Alias: ja.Alias, Canonical: ja.Canonical, IPA: ja.SymbolType == "IPA", Deprecated: ja.Deprecated})
+	}
+	var modifiers = make([]Modifier, 0)
+	for _, jm := range jss.Modifiers {
+		m := Modifier{String: jm.Symbol, IPA: jm.IPA}
+		for _, c := range jm.Categories {
+			cat, err := symbolCatFromString(c)
+			if err != nil {
+				return nilRes, fmt.Errorf("couldn't load category for modifier %s : %w", jm.Symbol, err)
+			}
+			m.Categories = append(m.Categories, cat)
+		}
+		modifiers = append(modifiers, m)
+	}
+	var testLines = make([]string, 0)
+	for _, t := range jss.Tests {
+		testLines = append(testLines, strings.Join([]string{"TEST", t.Type, t.SymbolType, t.Trans}, "\t"))
+	}
+	form := FormNone
+	if len(jss.Normalization) > 0 {
+		var err error
+		form, err = NormalizationFormFromString(jss.Normalization)
+		if err != nil {
+			return nilRes, err
+		}
+	}
+	stressPlacement := StressUndefined
+	if len(jss.StressPlacement) > 0 {
+		var err error
+		stressPlacement, err = StressPlacementFromString(jss.StressPlacement)
+		if err != nil {
+			return nilRes, err
+		}
+	}
+	var disabledRules []Rule
+	for _, r := range jss.DisabledRules {
+		rule, err := RuleFromString(r)
+		if err != nil {
+			return nilRes, err
+		}
+		disabledRules = append(disabledRules, rule)
+	}
+	metadata := map[string]string{
+		"TYPE":        jss.Type,
+		"LANGUAGE":    jss.Language,
+		"VERSION":     jss.Version,
+		"DESCRIPTION": jss.Description,
+		"LICENSE":     jss.License,
+		"SOURCE":      jss.Source,
+	}
+	for key, value := range metadata {
+		if len(value) == 0 {
+			delete(metadata, key)
+		}
+	}
+	ss, err := NewSymbolSetWithOptions(jss.Name, symbols, SymbolSetOptions{
+		Aliases:         aliases,
+		Modifiers:       modifiers,
+		TestLines:       testLines,
+		Normalization:   form,
+		StressPlacement: stressPlacement,
+		DisabledRules:   disabledRules,
+		Metadata:        metadata,
+	})
+	if err != nil {
+		return nilRes, err
+	}
+	return ss, nil
+}
+
+// WriteJSON writes the symbol set to the writer, in JSON format
+func (ss SymbolSet) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ss.ToJSON())
+}
+
+// ReadSymbolSetJSON reads a symbol set in JSON format
+func ReadSymbolSetJSON(r io.Reader) (SymbolSet, error) {
+	var jss JSONSymbolSet
+	if err := json.NewDecoder(r).Decode(&jss); err != nil {
+		return SymbolSet{}, fmt.Errorf("couldn't decode json : %w", err)
+	}
+	return NewSymbolSetFromJSON(jss)
+}
+
+// LoadSymbolSetJSON loads a symbol set from a JSON file
+func LoadSymbolSetJSON(fName string) (SymbolSet, error) {
+	fh, err := os.Open(filepath.Clean(fName))
+	if err != nil {
+		return SymbolSet{}, err
+	}
+	/* #nosec G307 */
+	defer fh.Close()
+	ss, err := ReadSymbolSetJSON(fh)
+	if err != nil {
+		return SymbolSet{}, fmt.Errorf("couldn't load symbol set from file %v : %w", fName, err)
+	}
+	return ss, nil
+}
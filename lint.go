@@ -0,0 +1,238 @@
+package symbolset
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stts-se/symbolset/ipa"
+)
+
+// linting of symbol sets
+
+// Severity is the severity level of a lint issue
+type Severity int
+
+const (
+	// SeverityInfo is used for issues that are not necessarily problems, but may be worth checking
+	SeverityInfo Severity = iota
+
+	// SeverityWarning is used for probable errors in the symbol set
+	SeverityWarning
+
+	// SeverityError is used for errors that will cause problems when the symbol set is used
+	SeverityError
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", s)
+	}
+	return severityNames[s]
+}
+
+// SeverityFromString returns the severity with the given name (info, warning or error). Case is ignored.
+func SeverityFromString(s string) (Severity, error) {
+	for i, name := range severityNames {
+		if strings.EqualFold(s, name) {
+			return Severity(i), nil
+		}
+	}
+	return SeverityInfo, fmt.Errorf("invalid severity '%s' (expected one of %s)", s, strings.Join(severityNames, ", "))
+}
+
+// Lint issue codes
+const (
+	LintDuplicateSymbol           = "duplicate-symbol"
+	LintDuplicateIPA              = "duplicate-ipa"
+	LintUnknownIPA                = "unknown-ipa"
+	LintCategoryMismatch          = "category-mismatch"
+	LintMultiplePhonemeDelimiters = "multiple-phoneme-delimiters"
+	LintPrefixSymbol              = "prefix-symbol"
+)
+
+// LintIssue is a problem found in a symbol set by Lint
+type LintIssue struct {
+	// Line is the line number in the .sym file (0 if the symbol set was not loaded from file)
+	Line     int
+	Severity Severity
+	Code     string
+	Symbol   string
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%d: %s: %s: %s", i.Line, i.Severity, i.Code, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Code, i.Message)
+}
+
+// ipaModifierRanges are code point ranges for IPA characters not listed in the IPA character database (see package ipa)
+var ipaModifierRanges = [][2]rune{
+	{0x1D00, 0x1DBF}, // phonetic extensions
+	{0x2016, 0x2016}, // major group (alternative)
+	{0x203F, 0x2040}, // undertie and character tie
+	{0x207F, 0x207F}, // nasal release
+	{0x2191, 0x2198}, // upstep, downstep, global rise and fall (alternatives)
+}
+
+func isIPAModifier(r rune) bool {
+	for _, rng := range ipaModifierRanges {
+		if r >= rng[0] && r <= rng[1] {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	syllabicMark    = '̩'
+	syllabicMarkAlt = '̍'
+	nonSyllabicMark = '̯'
+)
+
+func (ss SymbolSet) symbolLineNumber(i int) int {
+	for _, l := range ss.layout {
+		if l.kind == symbolLine && l.index == i {
+			return l.line
+		}
+	}
+	return 0
+}
+
+// Lint checks the symbol set for possible problems, and returns the issues found, sorted by line number
+func Lint(ss SymbolSet) []LintIssue {
+	var res []LintIssue
+	add := func(i int, severity Severity, code string, msg string, args ...interface{}) {
+		res = append(res, LintIssue{
+			Line:     ss.symbolLineNumber(i),
+			Severity: severity,
+			Code:     code,
+			Symbol:   ss.Symbols[i].String,
+			Message:  fmt.Sprintf(msg, args...),
+		})
+	}
+
+	seenSymbols := make(map[string]int)
+	seenIPA := make(map[string]int)
+	for i, sym := range ss.Symbols {
+		if j, exists := seenSymbols[sym.String]; exists {
+			add(i, SeverityError, LintDuplicateSymbol, "duplicate symbol /%s/ (first defined on line %d)", sym.String, ss.symbolLineNumber(j))
+		} else {
+			seenSymbols[sym.String] = i
+		}
+
+		if !isPhoneticCat(sym.Cat) {
+			continue
+		}
+		if len(sym.IPA.String) > 0 {
+			if j, exists := seenIPA[sym.IPA.String]; exists {
+				add(i, SeverityWarning, LintDuplicateIPA, "duplicate ipa /%s/ for symbols /%s/ and /%s/ (first defined on line %d)", sym.IPA.String, ss.Symbols[j].String, sym.String, ss.symbolLineNumber(j))
+			} else {
+				seenIPA[sym.IPA.String] = i
+			}
+		}
+
+		hasVowel, hasConsonant := false, false
+		for _, r := range sym.IPA.String {
+			switch ipa.Classify(r) {
+			case ipa.Vowel:
+				hasVowel = true
+			case ipa.Consonant:
+				hasConsonant = true
+			case ipa.Unknown:
+				if isIPAModifier(r) {
+					continue
+				}
+				add(i, SeverityWarning, LintUnknownIPA, "ipa /%s/ for symbol /%s/ contains unknown IPA character '%c' (%s)", sym.IPA.String, sym.String, r, string2unicode(string(r)))
+			}
+		}
+		switch {
+		case sym.Cat == NonSyllabic && hasVowel && !hasConsonant && !strings.ContainsRune(sym.IPA.String, nonSyllabicMark):
+			add(i, SeverityWarning, LintCategoryMismatch, "symbol /%s/ is %s, but ipa /%s/ is a vowel", sym.String, sym.Cat, sym.IPA.String)
+		case sym.Cat == Syllabic && hasConsonant && !hasVowel && !strings.ContainsRune(sym.IPA.String, syllabicMark) && !strings.ContainsRune(sym.IPA.String, syllabicMarkAlt):
+			add(i, SeverityWarning, LintCategoryMismatch, "symbol /%s/ is %s, but ipa /%s/ is a consonant without syllabic mark", sym.String, sym.Cat, sym.IPA.String)
+		}
+	}
+
+	if len(ss.phonemeDelimiters) > 1 {
+		for i, sym := range ss.Symbols {
+			if sym.Cat == PhonemeDelimiter && sym != ss.PhonemeDelimiter {
+				add(i, SeverityWarning, LintMultiplePhonemeDelimiters, "more than one phoneme delimiter defined (/%s/ will be ignored when splitting transcriptions, using /%s/)", sym.String, ss.PhonemeDelimiter.String)
+			}
+		}
+	}
+
+	if ss.PhonemeDelimiter.String == "" {
+		for i, sym := range ss.Symbols {
+			if len(sym.String) == 0 {
+				continue
+			}
+			var longer []string
+			for _, other := range ss.Symbols {
+				if other.String != sym.String && strings.HasPrefix(other.String, sym.String) {
+					longer = append(longer, "/"+other.String+"/")
+				}
+			}
+			if len(longer) > 0 {
+				add(i, SeverityInfo, LintPrefixSymbol, "symbol /%s/ is a prefix of %s (transcriptions are split using longest match)", sym.String, strings.Join(longer, ", "))
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Line < res[j].Line })
+	return res
+}
+
+func isPhoneticCat(cat SymbolCat) bool {
+	return cat == Syllabic || cat == NonSyllabic || cat == Stress || cat == Tone || cat == Length || cat == Diacritic
+}
+
+// checkLint returns an error if any lint issues have the specified severity, or higher
+func checkLint(ss SymbolSet, failOn Severity) error {
+	var values []string
+	for _, issue := range Lint(ss) {
+		if issue.Severity >= failOn {
+			values = append(values, issue.String())
+		}
+	}
+	if len(values) > 0 {
+		return LintError(values)
+	}
+	return nil
+}
+
+// ReadSymbolSetStrict reads a SymbolSet in .sym format from the reader (see ReadSymbolSet), and returns an error if Lint finds any issues with the specified severity or higher
+func ReadSymbolSetStrict(name string, r io.Reader, failOn Severity) (SymbolSet, error) {
+	ss, err := ReadSymbolSet(name, r)
+	if err != nil {
+		return SymbolSet{}, err
+	}
+	if err := checkLint(ss, failOn); err != nil {
+		return SymbolSet{}, fmt.Errorf("lint failed for symbol set %s : %w", name, err)
+	}
+	return ss, nil
+}
+
+// LoadSymbolSetStrict loads a SymbolSet from file (see LoadSymbolSet), and returns an error if Lint finds any issues with the specified severity or higher
+func LoadSymbolSetStrict(fName string, failOn Severity) (SymbolSet, error) {
+	name := filepath.Base(fName)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	fh, err := os.Open(filepath.Clean(fName))
+	if err != nil {
+		return SymbolSet{}, err
+	}
+	/* #nosec G307 */
+	defer fh.Close()
+	ss, err := ReadSymbolSetStrict(name, fh, failOn)
+	if err != nil {
+		return SymbolSet{}, fmt.Errorf("couldn't load symbol set from file %v : %w", fName, err)
+	}
+	return ss, nil
+}
@@ -0,0 +1,105 @@
+package symbolset
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func lintCodes(issues []LintIssue) string {
+	var res []string
+	for _, i := range issues {
+		res = append(res, i.Code)
+	}
+	return strings.Join(res, " ")
+}
+
+func Test_Lint(t *testing.T) {
+	input := strings.Join([]string{
+		header,
+		"sil	i:	iː	U+0069U+02D0	Syllabic",
+		"bok	b	b	U+0062	NonSyllabic",
+		"bok	b	b	U+0062	NonSyllabic",
+		"katt	a	a	U+0061	NonSyllabic",
+		"pil	p	p:	U+0070U+003A	NonSyllabic",
+		"mil	m	m	U+006D	Syllabic",
+		"phoneme delimiter	 			PhonemeDelimiter",
+		"phoneme delimiter	_			PhonemeDelimiter",
+	}, "\n")
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	issues := Lint(ss)
+	expect := "duplicate-symbol duplicate-ipa category-mismatch unknown-ipa category-mismatch multiple-phoneme-delimiters"
+	if lintCodes(issues) != expect {
+		t.Errorf(fsExp, expect, issues)
+	}
+	if len(issues) > 0 && (issues[0].Line != 4 || issues[0].Severity != SeverityError || issues[0].Symbol != "b") {
+		t.Errorf("unexpected issue %v", issues[0])
+	}
+}
+
+func Test_Lint_TestData(t *testing.T) {
+	for _, fName := range []string{"test_data/sv-se_ws-sampa.sym", "test_data/en-us_cmu.sym", "test_data/sv-se_nst-xsampa.sym"} {
+		ss, err := LoadSymbolSetStrict(fName, SeverityWarning)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		for _, issue := range Lint(ss) {
+			if issue.Code != LintPrefixSymbol {
+				t.Errorf("%s: unexpected lint issue %v", fName, issue)
+			}
+		}
+	}
+}
+
+func Test_Lint_PrefixSymbol(t *testing.T) {
+	input := strings.Join([]string{
+		header,
+		"tak	t	t	U+0074	NonSyllabic",
+		"tjock	tS	tʃ	U+0074U+0283	NonSyllabic",
+		"sil	i	i	U+0069	Syllabic",
+		"phoneme delimiter				PhonemeDelimiter",
+	}, "\n")
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	issues := Lint(ss)
+	if lintCodes(issues) != LintPrefixSymbol || issues[0].Severity != SeverityInfo || issues[0].Line != 2 {
+		t.Errorf("unexpected lint issues %v", issues)
+	}
+}
+
+func Test_ReadSymbolSetStrict(t *testing.T) {
+	input := strings.Join([]string{
+		header,
+		"sil	i:	iː	U+0069U+02D0	Syllabic",
+		"katt	a	a	U+0061	NonSyllabic",
+		"phoneme delimiter	 			PhonemeDelimiter",
+	}, "\n")
+	if _, err := ReadSymbolSetStrict("test", strings.NewReader(input), SeverityError); err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	_, err := ReadSymbolSetStrict("test", strings.NewReader(input), SeverityWarning)
+	var ssErr *SymbolSetError
+	if !errors.As(err, &ssErr) || ssErr.ErrorCode != ErrCodeLint {
+		t.Errorf("expected lint error, found %v", err)
+	}
+}
+
+func Test_SeverityFromString(t *testing.T) {
+	for _, s := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		result, err := SeverityFromString(s.String())
+		if err != nil || result != s {
+			t.Errorf(fsExp, s, result)
+		}
+	}
+	if _, err := SeverityFromString("fatal"); err == nil {
+		t.Errorf("expected error for invalid severity")
+	}
+}
@@ -13,7 +13,7 @@ func LoadMapper(s1 symbolset.SymbolSet, s2 symbolset.SymbolSet) (Mapper, error)
 	toName := s2.Name
 	name := fromName + " - " + toName
 
-	mapper := Mapper{name, s1, s2}
+	mapper := Mapper{Name: name, SymbolSet1: s1, SymbolSet2: s2}
 
 	var err error
 
@@ -38,6 +38,19 @@ func LoadMapper(s1 symbolset.SymbolSet, s2 symbolset.SymbolSet) (Mapper, error)
 	return mapper, nil
 }
 
+// LoadMapperWithSyllabifier loads a symbol set mapper from two SymbolSet instances, using the syllabifier to insert syllable delimiters before mapping. The syllabifier should be defined for the left hand symbol set.
+func LoadMapperWithSyllabifier(s1 symbolset.SymbolSet, s2 symbolset.SymbolSet, syllabifier symbolset.Syllabifier) (Mapper, error) {
+	if syllabifier.SymbolSet.Name != s1.Name {
+		return Mapper{}, fmt.Errorf("syllabifier symbol set %s does not match mapper input symbol set %s", syllabifier.SymbolSet.Name, s1.Name)
+	}
+	mapper, err := LoadMapper(s1, s2)
+	if err != nil {
+		return mapper, err
+	}
+	mapper.Syllabifier = &syllabifier
+	return mapper, nil
+}
+
 // LoadMapperFromFile loads two SymbolSet instances from files.
 func LoadMapperFromFile(fromName string, toName string, fName1 string, fName2 string) (Mapper, error) {
 
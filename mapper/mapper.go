@@ -11,10 +11,20 @@ type Mapper struct {
 	Name       string
 	SymbolSet1 symbolset.SymbolSet
 	SymbolSet2 symbolset.SymbolSet
+
+	// Syllabifier is an optional pre-step, used to insert syllable delimiters into the input transcription before mapping
+	Syllabifier *symbolset.Syllabifier
 }
 
 // MapTranscription maps one input transcription string into the new symbol set.
 func (m Mapper) MapTranscription(input string) (string, error) {
+	if m.Syllabifier != nil {
+		syllabified, err := m.Syllabifier.Syllabify(input)
+		if err != nil {
+			return "", fmt.Errorf("couldn't syllabify transcription : %w", err)
+		}
+		input = syllabified
+	}
 	res, err := m.SymbolSet1.ConvertToInternalIPA(input)
 	if err != nil {
 		return "", fmt.Errorf("couldn't map transcription (1) : %w", err)
@@ -20,18 +20,18 @@ func testMapTranscription(t *testing.T, mapper Mapper, input string, expect stri
 
 func Test_MapTranscription_EmptyDelimiterInInput1(t *testing.T) {
 	symbols1 := []symbolset.Symbol{
-		{"a", symbolset.Syllabic, "", symbolset.IPASymbol{"a", "U+0061"}},
-		{"r", symbolset.NonSyllabic, "", symbolset.IPASymbol{"r", "U+0072"}},
-		{"t", symbolset.NonSyllabic, "", symbolset.IPASymbol{"t", "U+0074"}},
-		{"r*t", symbolset.NonSyllabic, "", symbolset.IPASymbol{"R", "U+0052"}},
-		{"", symbolset.PhonemeDelimiter, "", symbolset.IPASymbol{"", ""}},
+		{String: "a", Cat: symbolset.Syllabic, Desc: "", IPA: symbolset.IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "r", Cat: symbolset.NonSyllabic, Desc: "", IPA: symbolset.IPASymbol{String: "r", Unicode: "U+0072"}},
+		{String: "t", Cat: symbolset.NonSyllabic, Desc: "", IPA: symbolset.IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: "r*t", Cat: symbolset.NonSyllabic, Desc: "", IPA: symbolset.IPASymbol{String: "R", Unicode: "U+0052"}},
+		{String: "", Cat: symbolset.PhonemeDelimiter, Desc: "", IPA: symbolset.IPASymbol{String: "", Unicode: ""}},
 	}
 	symbols2 := []symbolset.Symbol{
-		{"A", symbolset.Syllabic, "", symbolset.IPASymbol{"a", "U+0061"}},
-		{"R", symbolset.NonSyllabic, "", symbolset.IPASymbol{"r", "U+0072"}},
-		{"T", symbolset.NonSyllabic, "", symbolset.IPASymbol{"t", "U+0074"}},
-		{"RT", symbolset.NonSyllabic, "", symbolset.IPASymbol{"R", "U+0052"}},
-		{" ", symbolset.PhonemeDelimiter, "", symbolset.IPASymbol{"", ""}},
+		{String: "A", Cat: symbolset.Syllabic, Desc: "", IPA: symbolset.IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "R", Cat: symbolset.NonSyllabic, Desc: "", IPA: symbolset.IPASymbol{String: "r", Unicode: "U+0072"}},
+		{String: "T", Cat: symbolset.NonSyllabic, Desc: "", IPA: symbolset.IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: "RT", Cat: symbolset.NonSyllabic, Desc: "", IPA: symbolset.IPASymbol{String: "R", Unicode: "U+0052"}},
+		{String: " ", Cat: symbolset.PhonemeDelimiter, Desc: "", IPA: symbolset.IPASymbol{String: "", Unicode: ""}},
 	}
 	ss1, err := symbolset.NewSymbolSet("sampa1", symbols1)
 	if err != nil {
@@ -566,3 +566,200 @@ func Test_MapperFromFile_CMU2WS_WithSyllDelim(t *testing.T) {
 	// 	t.Errorf("Expected error here!")
 	// }
 }
+
+func Test_MapperFromFile_CMU2WS_WithSyllabifier(t *testing.T) {
+	cmu, err := symbolset.LoadSymbolSetWithName("ENU-CMU", "../test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSetWithName() didn't expect error here : %v", err)
+		return
+	}
+	ws, err := symbolset.LoadSymbolSetWithName("ENU-WS", "../test_data/en-us_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSetWithName() didn't expect error here : %v", err)
+		return
+	}
+	syllabifier, err := symbolset.NewSyllabifier(cmu, symbolset.SyllabificationRules{})
+	if err != nil {
+		t.Errorf("NewSyllabifier() didn't expect error here : %v", err)
+		return
+	}
+	mapper, err := LoadMapperWithSyllabifier(cmu, ws, syllabifier)
+	if err != nil {
+		t.Errorf("LoadMapperWithSyllabifier() didn't expect error here : %v", err)
+		return
+	}
+
+	testMapTranscription(t, mapper, "P L AE1 T AX P UH2 S", "' p l { . t @ . % p U s")
+	testMapTranscription(t, mapper, "P L AE1 $ T AX $ P UH2 S", "' p l { . t @ . % p U s")
+}
+
+// lexicon-scale input for the mapping benchmarks
+func benchmarkLexicon(transes []string, n int) []string {
+	var res = make([]string, n)
+	for i := range res {
+		res[i] = transes[i%len(transes)]
+	}
+	return res
+}
+
+func benchmarkMapTranscriptions(b *testing.B, mapper Mapper, input []string) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := mapper.MapTranscriptions(input); err != nil {
+			b.Fatalf("MapTranscriptions() didn't expect error here : %v", err)
+		}
+	}
+	b.ReportMetric(float64(len(input)*b.N)/b.Elapsed().Seconds(), "trans/s")
+}
+
+func Benchmark_MapTranscriptions_NST2WS(b *testing.B) {
+	mapper, err := LoadMapperFromFile("SAMPA", "SYMBOL", "../test_data/nb-no_nst-xsampa.sym", "../test_data/nb-no_ws-sampa.sym")
+	if err != nil {
+		b.Fatalf("LoadMapperFromFile() didn't expect error here : %v", err)
+	}
+	input := benchmarkLexicon([]string{"\"A:$bl@s", "\"tSE$kIsk", "\"\"b9$n@r", "\"b9$n@r", "b\"9n"}, 100000)
+	benchmarkMapTranscriptions(b, mapper, input)
+}
+
+func Benchmark_MapTranscriptions_CMU2WS(b *testing.B) {
+	mapper, err := LoadMapperFromFile("ENU-CMU", "ENU-WS", "../test_data/en-us_cmu.sym", "../test_data/en-us_ws-sampa.sym")
+	if err != nil {
+		b.Fatalf("LoadMapperFromFile() didn't expect error here : %v", err)
+	}
+	input := benchmarkLexicon([]string{"P L AE1 $ T AX $ P UH2 S", "P L AE1 $ T AX", "T AX $ P UH2 S"}, 100000)
+	benchmarkMapTranscriptions(b, mapper, input)
+}
+
+func Test_MapperFromFile_WS2CMU_StressPlacement(t *testing.T) {
+	mapper, err := LoadMapperFromFile("ENU-WS", "ENU-CMU", "../test_data/en-us_ws-sampa.sym", "../test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("Test_LoadMapperFromFile() didn't expect error here : %v", err)
+		return
+	}
+	testMapTranscription(t, mapper, "' p l { . t @ . % p U s", "P L AE1 $ T AX $ P UH2 S")
+}
+
+func Test_Service_LoadBuiltins(t *testing.T) {
+	s := Service{SymbolSets: make(map[string]symbolset.SymbolSet), Mappers: make(map[string]Mapper)}
+	if err := s.Load("../test_data/sv-se_ws-sampa.sym"); err != nil {
+		t.Fatalf("Load() didn't expect error here : %v", err)
+	}
+	if err := s.Load("../test_data/en-us_cmu.sym"); err != nil {
+		t.Fatalf("Load() didn't expect error here : %v", err)
+	}
+	if err := s.LoadBuiltins(); err != nil {
+		t.Fatalf("LoadBuiltins() didn't expect error here : %v", err)
+	}
+
+	for _, test := range []struct {
+		from, to, input, expect string
+	}{
+		{"sv-se_ws-sampa", "x-sampa", "\"\" b r A: . k a", "\"_LbrA:.ka"},
+		{"sv-se_ws-sampa", "x-sampa", "\" t E n", "\"tEn"},
+		{"sv-se_ws-sampa", "kirshenbaum", "\" t E n", "'tEn"},
+		{"x-sampa", "kirshenbaum", "\"tSIk@n", "'tSIk@n"},
+		{"kirshenbaum", "x-sampa", "'k<h>&t", "\"k_h{t"},
+		{"arpabet", "x-sampa", "P L AE1 . T AH0", "\"pl{.tV"},
+		{"x-sampa", "arpabet", "\"pl{.tV", "P L AE1 . T AH"},
+		{"arpabet", "kirshenbaum", "CH IH1 K AX N", "'tSIk@n"},
+		{"en-us_cmu", "arpabet", "P L AE1 $ T AX $ P UH2 S", "P L AE1 . T AX . P UH2 S"},
+		{"ipa", "x-sampa", "ˈtʰɛn", "\"t_hEn"},
+		{"arpabet", "ipa", "P L AE1 . T AH0", "ˈplæ.tʌ"},
+	} {
+		result, err := s.Map(test.from, test.to, test.input)
+		if err != nil {
+			t.Errorf("Map() didn't expect error here; %s => %s /%s/ : %v", test.from, test.to, test.input, err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("%s => %s: "+fsExpTrans, test.from, test.to, test.expect, result)
+		}
+	}
+}
+
+func Test_Service_LoadBuiltins_KeepsExisting(t *testing.T) {
+	s := Service{SymbolSets: make(map[string]symbolset.SymbolSet), Mappers: make(map[string]Mapper)}
+	ss, err := symbolset.LoadSymbolSetWithName("x-sampa", "../test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		t.Fatalf("LoadSymbolSetWithName() didn't expect error here : %v", err)
+	}
+	s.Add(ss)
+	if err := s.LoadBuiltins(); err != nil {
+		t.Fatalf("LoadBuiltins() didn't expect error here : %v", err)
+	}
+	if got, exp := len(s.SymbolSets["x-sampa"].Symbols), len(ss.Symbols); got != exp {
+		t.Errorf("expected the existing x-sampa symbol set to be kept (%d symbols), found %d symbols", exp, got)
+	}
+	if _, ok := s.SymbolSets["arpabet"]; !ok {
+		t.Errorf("expected built-in symbol set arpabet to be loaded")
+	}
+}
+
+func Test_Service_MapFromGeneralIPA(t *testing.T) {
+	s := Service{SymbolSets: make(map[string]symbolset.SymbolSet), Mappers: make(map[string]Mapper)}
+	if err := s.LoadBuiltins(); err != nil {
+		t.Fatalf("LoadBuiltins() didn't expect error here : %v", err)
+	}
+	// x-sampa has symbols for all the diacritics
+	res, approximations, err := s.MapFromGeneralIPA("x-sampa", "[ˈtʰɑ̝ːk]")
+	if err != nil {
+		t.Fatalf("MapFromGeneralIPA() didn't expect error here : %v", err)
+	}
+	if exp := `"t_hA_r:k`; res != exp || len(approximations) != 0 {
+		t.Errorf(fsExpTrans, exp, res)
+		t.Errorf("expected no approximations, found %v", approximations)
+	}
+
+	res, approximations, err = s.MapFromGeneralIPA("arpabet", "[ˈtʰɑ̝ːk]")
+	if err != nil {
+		t.Fatalf("MapFromGeneralIPA() didn't expect error here : %v", err)
+	}
+	if exp := "T AA1 K"; res != exp {
+		t.Errorf(fsExpTrans, exp, res)
+	}
+	if len(approximations) != 2 || approximations[0].To != "t" || approximations[1].To != "ɑ" {
+		t.Errorf("expected approximations to /t/ and /ɑ/, found %v", approximations)
+	}
+	if _, _, err := s.MapFromGeneralIPA("unknown", "a"); err == nil {
+		t.Errorf("expected error for unknown symbol set")
+	}
+}
+
+func Test_Service_InputDiagnostics(t *testing.T) {
+	s := Service{SymbolSets: make(map[string]symbolset.SymbolSet), Mappers: make(map[string]Mapper)}
+	if err := s.LoadBuiltins(); err != nil {
+		t.Fatalf("LoadBuiltins() didn't expect error here : %v", err)
+	}
+
+	// arpabet has no length mark: the input is valid, but can't be mapped
+	trans := `t"A:k`
+	if _, err := s.Map("x-sampa", "arpabet", trans); err == nil {
+		t.Errorf("Map() expected error here")
+	}
+	diags, err := s.InputDiagnostics("x-sampa", "arpabet", trans)
+	if err != nil {
+		t.Fatalf("InputDiagnostics() didn't expect error here : %v", err)
+	}
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics for /%s/, found %v", trans, diags)
+	}
+
+	// unknown symbol in the input
+	trans = `"t¤A:k`
+	diags, err = s.InputDiagnostics("x-sampa", "arpabet", trans)
+	if err != nil {
+		t.Fatalf("InputDiagnostics() didn't expect error here : %v", err)
+	}
+	if len(diags) != 1 || diags[0].ErrorCode != symbolset.ErrCodeUnknownInputSymbol || diags[0].Token != "¤" {
+		t.Errorf("expected unknown symbol '¤' in /%s/, found %v", trans, diags)
+	}
+
+	diags, err = s.InputDiagnostics("ipa", "arpabet", "ˈtɑːk")
+	if err != nil {
+		t.Fatalf("InputDiagnostics() didn't expect error here : %v", err)
+	}
+	if len(diags) != 1 || diags[0].Token != "ː" {
+		t.Errorf("expected unknown symbol 'ː' in IPA input, found %v", diags)
+	}
+}
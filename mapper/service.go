@@ -1,11 +1,13 @@
 package mapper
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/stts-se/symbolset"
+	"github.com/stts-se/symbolset/builtin"
 )
 
 // functions for use by the mapper http service
@@ -63,6 +65,31 @@ func (s Service) Load(symbolSetFile string) error {
 	}
 	s.SymbolSets[ss.Name] = ss
 	log.Printf("Loaded symbol set %v into cache", ss.Name)
+	for _, amb := range ss.Ambiguities() {
+		log.Printf("Symbol set %v has ambiguous segmentations: %v", ss.Name, amb)
+	}
+	return nil
+}
+
+// Add is used to add a symbol set to the cache (e.g., a symbol set that wasn't loaded from file). An existing symbol set with the same name is replaced.
+func (s Service) Add(ss symbolset.SymbolSet) {
+	s.SymbolSets[ss.Name] = ss
+	log.Printf("Added symbol set %v to cache", ss.Name)
+}
+
+// LoadBuiltins is used to load the built-in symbol sets (X-SAMPA, Kirshenbaum and ARPAbet, see package builtin). Symbol sets already in the cache with the same name are kept, so that file-based symbol sets take precedence.
+func (s Service) LoadBuiltins() error {
+	symbolSets, err := builtin.LoadAll()
+	if err != nil {
+		return fmt.Errorf("couldn't load built-in symbol sets : %w", err)
+	}
+	for name, ss := range symbolSets {
+		if _, ok := s.SymbolSets[name]; ok {
+			log.Printf("Built-in symbol set %v is already defined, keeping the existing one", name)
+			continue
+		}
+		s.Add(ss)
+	}
 	return nil
 }
 
@@ -133,6 +160,50 @@ func (s Service) Map(fromName string, toName string, trans string) (string, erro
 	}
 }
 
+// MapFromGeneralIPA is used by the server to map a general IPA transcription (e.g., from Wiktionary) into a symbol set, replacing unknown IPA segments with the closest symbols.
+// See symbolset.SymbolSet.ConvertFromGeneralIPA.
+func (s Service) MapFromGeneralIPA(toName string, trans string) (string, []symbolset.Approximation, error) {
+	ss, ok := s.SymbolSets[toName]
+	if !ok {
+		return "", nil, symbolset.UnknownSymbolSet([]string{toName})
+	}
+	return ss.ConvertFromGeneralIPA(trans)
+}
+
+// Validate is used by the server to locate the errors in an input transcription to be mapped from one symbol set to another
+func (s Service) Validate(fromName string, toName string, trans string) ([]symbolset.Diagnostic, error) {
+	if fromName == "ipa" {
+		ss, ok := s.SymbolSets[toName]
+		if !ok {
+			return nil, symbolset.UnknownSymbolSet([]string{toName})
+		}
+		return ss.ValidateInternalIPA(trans), nil
+	}
+	ss, ok := s.SymbolSets[fromName]
+	if !ok {
+		return nil, symbolset.UnknownSymbolSet([]string{fromName})
+	}
+	return ss.Validate(trans), nil
+}
+
+// InputDiagnostics is used by the server to locate the errors in an input transcription that couldn't be mapped from one symbol set to another.
+// Diagnostics are only returned if the input transcription has unknown symbols: if the mapping failed because the target symbol set is missing symbols, the input is valid, and no diagnostics are returned.
+func (s Service) InputDiagnostics(fromName string, toName string, trans string) ([]symbolset.Diagnostic, error) {
+	if fromName == "ipa" || toName == "ipa" {
+		// a single conversion: the symbol set used validates the input
+		return s.Validate(fromName, toName, trans)
+	}
+	mapper, err := s.getOrCreateMapper(fromName, toName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create mapper from %s to %s : %w", fromName, toName, err)
+	}
+	var sse *symbolset.SymbolSetError
+	if _, err := mapper.SymbolSet1.ConvertToInternalIPA(trans); !errors.As(err, &sse) || sse.ErrorCode != symbolset.ErrCodeUnknownInputSymbol {
+		return nil, nil
+	}
+	return mapper.SymbolSet1.Validate(trans), nil
+}
+
 // GetMapTable is used by the server to show/get a mapping table between two symbol sets
 func (s Service) GetMapTable(fromName string, toName string) (Mapper, error) {
 	mapper, err := s.getOrCreateMapper(fromName, toName)
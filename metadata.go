@@ -0,0 +1,75 @@
+package symbolset
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// metadata directives in .sym files
+
+// Metadata directives can be added to a .sym file to describe the symbol set, one directive per line, e.g.:
+//
+//	TYPE	SAMPA
+//	LANGUAGE	sv-SE
+var metadataDirectives = []string{"TYPE", "LANGUAGE", "VERSION", "DESCRIPTION", "LICENSE", "SOURCE"}
+
+func isMetadataLine(l string) bool {
+	fs := strings.Split(l, "\t")
+	return len(fs) == 2 && slices.Contains(metadataDirectives, fs[0])
+}
+
+func parseMetadataLine(l string) (string, string, error) {
+	fs := strings.Split(l, "\t")
+	if len(fs) != 2 {
+		return "", "", fmt.Errorf("metadata line must have 2 fields, found %s", l)
+	}
+	value := strings.TrimSpace(fs[1])
+	if len(value) == 0 {
+		return "", "", fmt.Errorf("empty value for metadata %s", fs[0])
+	}
+	return fs[0], value, nil
+}
+
+// TypeFromString returns the symbol set type with the given name (as returned by Type.String). Case is ignored.
+func TypeFromString(s string) (Type, error) {
+	for _, t := range []Type{CMU, SAMPA, IPA, Other} {
+		if strings.EqualFold(s, t.String()) {
+			return t, nil
+		}
+	}
+	return Other, fmt.Errorf("invalid symbol set type '%s' (expected one of %s, %s, %s, %s)", s, CMU, SAMPA, IPA, Other)
+}
+
+// applyMetadata sets the metadata fields of the symbol set. The TYPE directive overrides the type derived from the symbol set name.
+func applyMetadata(ss *SymbolSet, metadata map[string]string) error {
+	for key, value := range metadata {
+		switch key {
+		case "TYPE":
+			t, err := TypeFromString(value)
+			if err != nil {
+				return err
+			}
+			ss.Type = t
+		case "LANGUAGE":
+			tag, err := language.Parse(value)
+			if err != nil {
+				return fmt.Errorf("invalid language tag '%s' : %w", value, err)
+			}
+			ss.Language = tag.String()
+		case "VERSION":
+			ss.Version = value
+		case "DESCRIPTION":
+			ss.Description = value
+		case "LICENSE":
+			ss.License = value
+		case "SOURCE":
+			ss.Source = value
+		default:
+			return fmt.Errorf("unknown metadata directive %s", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package symbolset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_LoadSymbolSet_Metadata(t *testing.T) {
+	// the name doesn't contain the type, so the type is taken from the TYPE directive
+	ss, err := LoadSymbolSetWithName("sv-se_nst", "test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	if ss.Type != SAMPA {
+		t.Errorf("Expected symbol set type %#v, got %#v", SAMPA.String(), ss.Type.String())
+	}
+	if ss.Language != "sv-SE" {
+		t.Errorf(fsExp, "sv-SE", ss.Language)
+	}
+	if ss.Version != "1.0" {
+		t.Errorf(fsExp, "1.0", ss.Version)
+	}
+	if ss.Description != "NST X-SAMPA for Swedish" {
+		t.Errorf(fsExp, "NST X-SAMPA for Swedish", ss.Description)
+	}
+	if ss.License != "" || ss.Source != "" {
+		t.Errorf("Expected empty license and source, got '%s' and '%s'", ss.License, ss.Source)
+	}
+}
+
+func Test_LoadSymbolSet_InvalidMetadata(t *testing.T) {
+	var tests = []string{
+		"TYPE	ARPABET\n",
+		"LANGUAGE	not a language\n",
+		"VERSION	1.0\nVERSION	1.1\n",
+		"SOURCE	\n",
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		fName := filepath.Join(dir, "test.sym")
+		content := header + "\n" + test + "sil	i:	iː	U+0069U+02D0	Syllabic\n"
+		if err := os.WriteFile(fName, []byte(content), 0600); err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			return
+		}
+		if _, err := LoadSymbolSet(fName); err == nil {
+			t.Errorf("Expected error for metadata %q", test)
+		}
+	}
+}
+
+func Test_TypeFromString(t *testing.T) {
+	for _, tp := range []Type{CMU, SAMPA, IPA, Other} {
+		result, err := TypeFromString(tp.String())
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+		}
+		if result != tp {
+			t.Errorf(fsExp, tp, result)
+		}
+	}
+	if result, _ := TypeFromString("sampa"); result != SAMPA {
+		t.Errorf(fsExp, SAMPA, result)
+	}
+	if _, err := TypeFromString("InternalIPA"); err == nil {
+		t.Errorf("Expected error for InternalIPA")
+	}
+}
+
+func Test_NewSymbolSetWithOptions_Metadata(t *testing.T) {
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "t", Cat: NonSyllabic, IPA: IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: " ", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	opts := SymbolSetOptions{
+		StressPlacement: StressBeforeNucleus,
+		DisabledRules:   []Rule{RuleSyllableNucleus},
+		Metadata:        map[string]string{"TYPE": "SAMPA", "LANGUAGE": "sv-SE"},
+	}
+	ss, err := NewSymbolSetWithOptions("test", symbols, opts)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if ss.Type != SAMPA {
+		t.Errorf(fsExp, SAMPA, ss.Type)
+	}
+	if ss.Language != "sv-SE" {
+		t.Errorf(fsExp, "sv-SE", ss.Language)
+	}
+	if ss.StressPlacement != StressBeforeNucleus {
+		t.Errorf(fsExp, StressBeforeNucleus, ss.StressPlacement)
+	}
+	if ss.RuleEnabled(RuleSyllableNucleus) {
+		t.Errorf("Expected rule %v to be disabled", RuleSyllableNucleus)
+	}
+
+	opts.Metadata = map[string]string{"TYPE": "ARPABET"}
+	if _, err := NewSymbolSetWithOptions("test", symbols, opts); err == nil {
+		t.Errorf("Expected error for metadata %v", opts.Metadata)
+	}
+}
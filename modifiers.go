@@ -0,0 +1,184 @@
+package symbolset
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// compositional modifier symbols
+
+// Modifier is a symbol that can be attached to base symbols without a phoneme delimiter, such as a length mark or a diacritic.
+// Modifiers are declared in the .sym file using MODIFIER lines, with the modifier symbol, its IPA string and the categories of the base symbols it attaches to (comma separated):
+//
+//	MODIFIER	:	ː	Syllabic
+//	MODIFIER	~	̃	Syllabic,NonSyllabic
+//
+// When the symbol set is created, each base symbol in the listed categories is combined with the modifiers, in the order they are declared (e.g., a, a~, a: and a~:).
+// The combined symbols are valid symbols, and the IPA strings are combined in the same way (a~: is mapped to /ãː/).
+// Symbols that are listed explicitly take precedence over combined symbols with the same symbol or IPA string, and a modifier is not added to a base symbol whose IPA already contains it.
+//
+// Since each combination of modifiers is a symbol in the symbol set, the number of combined symbols grows quickly with the number of modifiers that can be attached to the same base symbols.
+// A symbol set with more than maxComposedSymbols combined symbols is rejected: restrict the categories of the modifiers, or list the combinations that are used explicitly.
+type Modifier struct {
+	// String is the modifier symbol
+	String string
+
+	// IPA is the IPA string of the modifier
+	IPA string
+
+	// Categories are the categories of the base symbols that the modifier can be attached to
+	Categories []SymbolCat
+}
+
+// line returns the modifier in .sym format
+func (m Modifier) line() string {
+	var cats []string
+	for _, c := range m.Categories {
+		cats = append(cats, c.String())
+	}
+	return strings.Join([]string{modifierDirective, m.String, m.IPA, strings.Join(cats, ",")}, "\t")
+}
+
+var modifierDirective = "MODIFIER"
+
+func isModifierLine(l string) bool {
+	return strings.HasPrefix(l, modifierDirective+"\t")
+}
+
+func parseModifierLine(l string) (Modifier, error) {
+	fs := strings.Split(l, "\t")
+	if len(fs) != 4 {
+		return Modifier{}, fmt.Errorf("%s line must have 4 fields, found %s", modifierDirective, l)
+	}
+	var cats []SymbolCat
+	for _, s := range strings.Split(fs[3], ",") {
+		cat, err := symbolCatFromString(strings.TrimSpace(s))
+		if err != nil {
+			return Modifier{}, fmt.Errorf("invalid category for modifier /%s/ : %w", fs[1], err)
+		}
+		cats = append(cats, cat)
+	}
+	return Modifier{String: fs[1], IPA: fs[2], Categories: cats}, nil
+}
+
+func (m Modifier) appliesTo(cat SymbolCat) bool {
+	for _, c := range m.Categories {
+		if c == cat {
+			return true
+		}
+	}
+	return false
+}
+
+// checkModifiers checks that the modifiers have non-empty symbol and IPA strings, without white space, and that each modifier is declared only once
+func checkModifiers(modifiers []Modifier) error {
+	seen := make(map[string]bool)
+	for _, m := range modifiers {
+		if len(m.String) == 0 || len(m.IPA) == 0 {
+			return fmt.Errorf("modifier /%s/ must have non-empty symbol and ipa strings", m.String)
+		}
+		if strings.IndexFunc(m.String+m.IPA, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("modifier /%s/ cannot contain white space", m.String)
+		}
+		if len(m.Categories) == 0 {
+			return fmt.Errorf("no categories defined for modifier /%s/", m.String)
+		}
+		if seen[m.String] {
+			return fmt.Errorf("modifier /%s/ is defined more than once", m.String)
+		}
+		seen[m.String] = true
+	}
+	return nil
+}
+
+// normalizeModifiers returns a copy of the modifiers, with the IPA strings normalized to the specified form
+func normalizeModifiers(modifiers []Modifier, form NormalizationForm) []Modifier {
+	res := make([]Modifier, len(modifiers))
+	for i, m := range modifiers {
+		m.IPA = form.normalize(m.IPA)
+		res[i] = m
+	}
+	return res
+}
+
+// maxComposedSymbols is the max number of symbols combined from base symbols and modifiers
+const maxComposedSymbols = 1024
+
+// maxModifiersPerSymbol is the max number of modifiers applicable to a base symbol (all combinations of more modifiers exceed maxComposedSymbols)
+const maxModifiersPerSymbol = 10
+
+// composeSymbols returns the symbols combined from the base symbols and the modifiers. Combinations with the same symbol string or IPA string as an explicit symbol (or a previous combination) are skipped.
+// The IPA strings of the combined symbols are normalized to the specified form. An error is returned if there are more than maxComposedSymbols combinations.
+func composeSymbols(symbols []Symbol, modifiers []Modifier, form NormalizationForm) ([]Symbol, error) {
+	if len(modifiers) == 0 {
+		return nil, nil
+	}
+	exists := make(map[string]bool, len(symbols))
+	ipaExists := make(map[string]bool, len(symbols))
+	for _, sym := range symbols {
+		exists[sym.String] = true
+		ipaExists[sym.IPA.String] = true
+	}
+	var res []Symbol
+	for _, base := range symbols {
+		if len(base.String) == 0 || len(base.IPA.String) == 0 {
+			continue
+		}
+		var mods []Modifier
+		for _, m := range modifiers {
+			// a modifier is not added to a base symbol that already contains it (e.g., a long vowel)
+			if m.appliesTo(base.Cat) && !strings.Contains(base.IPA.String, m.IPA) {
+				mods = append(mods, m)
+			}
+		}
+		if len(mods) > maxModifiersPerSymbol || len(res)+(1<<len(mods))-1 > maxComposedSymbols {
+			return nil, fmt.Errorf("too many combinations of modifiers and base symbols (max %d, found %d modifiers for /%s/)", maxComposedSymbols, len(mods), base.String)
+		}
+		// each non-empty subset of the applicable modifiers, in declaration order
+		for mask := 1; mask < 1<<len(mods); mask++ {
+			sym := base
+			var descs []string
+			for i, m := range mods {
+				if mask&(1<<i) != 0 {
+					sym.String += m.String
+					sym.IPA.String += m.IPA
+					descs = append(descs, strings.ToLower(DescribeIPA(m.IPA)))
+				}
+			}
+			sym.IPA.String = form.normalize(sym.IPA.String)
+			if exists[sym.String] || ipaExists[sym.IPA.String] {
+				continue
+			}
+			exists[sym.String] = true
+			ipaExists[sym.IPA.String] = true
+			sym.IPA.Unicode = string2unicode(sym.IPA.String)
+			sym.Desc = strings.TrimSpace(base.Description() + ", " + strings.Join(descs, ", "))
+			res = append(res, sym)
+		}
+	}
+	return res, nil
+}
+
+// ComposedSymbols returns the symbols combined from base symbols and modifiers (see Modifier). These are not included in Symbols.
+func (ss SymbolSet) ComposedSymbols() []Symbol {
+	return ss.composed
+}
+
+// allSymbols returns the explicit symbols, followed by the composed symbols
+func (ss SymbolSet) allSymbols() []Symbol {
+	if len(ss.composed) == 0 {
+		return ss.Symbols
+	}
+	res := make([]Symbol, 0, len(ss.Symbols)+len(ss.composed))
+	res = append(res, ss.Symbols...)
+	return append(res, ss.composed...)
+}
+
+// symbolAt returns the symbol at index i in allSymbols
+func (ss SymbolSet) symbolAt(i int) Symbol {
+	if i < len(ss.Symbols) {
+		return ss.Symbols[i]
+	}
+	return ss.composed[i-len(ss.Symbols)]
+}
@@ -0,0 +1,176 @@
+package symbolset
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var modifierTestInput = `DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY
+	a	a	U+0061	Syllabic
+	e	e	U+0065	Syllabic
+	u	u	U+0075	Syllabic
+	u:	ʉː	U+0289U+02D0	Syllabic
+	aa	aː	U+0061U+02D0	Syllabic
+	p	p	U+0070	NonSyllabic
+	t	t	U+0074	NonSyllabic
+	n	n	U+006E	NonSyllabic
+	"	ˈ	U+02C8	Stress
+	.	.	U+002E	SyllableDelimiter
+	 			PhonemeDelimiter
+MODIFIER	~	̃	Syllabic
+MODIFIER	:	ː	Syllabic
+MODIFIER	_h	ʰ	NonSyllabic
+`
+
+func Test_Modifiers_Convert(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(modifierTestInput))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var tests = []struct {
+		input  string
+		ipa    string
+		output string // from IPA
+	}{
+		{`" p_h a~: . t e:`, "ˈpʰãː.teː", `" p_h a~: . t e:`},
+		{`t u: . n e~`, "tʉː.nẽ", `t u: . n e~`}, // explicit symbol u: takes precedence over u + :
+		{`" p aa . t a~`, "ˈpaː.ta\u0303", `" p aa . t a~`},
+	}
+	for _, test := range tests {
+		result, err := ss.ConvertToInternalIPA(test.input)
+		if err != nil {
+			t.Errorf("/%s/: didn't expect error here : %v", test.input, err)
+			continue
+		}
+		if result != test.ipa {
+			t.Errorf("/%s/: "+fsExp, test.input, test.ipa, result)
+		}
+		result, err = ss.ConvertFromInternalIPA(test.ipa)
+		if err != nil {
+			t.Errorf("/%s/: didn't expect error here : %v", test.ipa, err)
+			continue
+		}
+		if result != test.output {
+			t.Errorf("/%s/: "+fsExp, test.ipa, test.output, result)
+		}
+	}
+
+	// modifiers only attach to base symbols in the declared categories, in the declared order
+	// a: is not created, since the explicit symbol aa has the same IPA /aː/
+	for _, s := range []string{"t:", "a_h", "a:~", "~", "a:"} {
+		if ss.ValidSymbol(s) {
+			t.Errorf("expected /%s/ to be invalid", s)
+		}
+	}
+	sym, err := ss.Get("e~:")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if sym.Cat != Syllabic || sym.IPA.Unicode != "U+0065U+0303U+02D0" {
+		t.Errorf("unexpected composed symbol %#v", sym)
+	}
+	// e has 3 combinations, a has 2 (/aː/ is explicit), u has 2 (u: is explicit), the long vowels u: and aa have 1, and the consonants have 1 each
+	if n := len(ss.ComposedSymbols()); n != 3+2+2+1+1+3 {
+		t.Errorf(fsExp, 3+2+2+1+1+3, n)
+	}
+	if n := len(ss.Symbols); n != 11 {
+		t.Errorf(fsExp, 11, n)
+	}
+}
+
+func Test_Modifiers_WithoutPhonemeDelimiter(t *testing.T) {
+	input := strings.Replace(modifierTestInput, "\t \t\t\tPhonemeDelimiter", "\t\t\t\tPhonemeDelimiter", 1)
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	splitted, err := ss.SplitTranscription(`"p_ha~:.te:`)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if result, expect := strings.Join(splitted, " "), `" p_h a~: . t e:`; result != expect {
+		t.Errorf(fsExp, expect, result)
+	}
+}
+
+func Test_Modifiers_WriteSym(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(modifierTestInput))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var buf bytes.Buffer
+	if err := ss.WriteSym(&buf); err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	// composed symbols are not written
+	if !strings.HasSuffix(buf.String(), "MODIFIER\t~\t\u0303\tSyllabic\nMODIFIER\t:\tː\tSyllabic\nMODIFIER\t_h\tʰ\tNonSyllabic\n") || strings.Contains(buf.String(), "\tp_h\t") {
+		t.Errorf("unexpected output\n%s", buf.String())
+	}
+
+	fromJSON, err := NewSymbolSetFromJSON(ss.ToJSON())
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if len(fromJSON.Modifiers) != 3 || !fromJSON.ValidSymbol("p_h") {
+		t.Errorf("expected modifiers to be kept in JSON, found %v", fromJSON.Modifiers)
+	}
+}
+
+func Test_Modifiers_DuplicateIPA(t *testing.T) {
+	input := strings.Replace(modifierTestInput, "\tu:\t", "\tE:\teː\tU+0065U+02D0\tSyllabic\n\tu:\t", 1)
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if ss.ValidSymbol("e:") {
+		t.Errorf("expected /e:/ to be invalid, since E: has the same IPA")
+	}
+	for _, sym := range ss.ComposedSymbols() {
+		if sym.IPA.String == "eː" {
+			t.Errorf("unexpected composed symbol %#v", sym)
+		}
+	}
+	if sym, err := ss.GetFromInternalIPA("eː"); err != nil || sym.String != "E:" {
+		t.Errorf("expected E: for /eː/, got %v (%v)", sym, err)
+	}
+}
+
+func Test_Modifiers_TooManyCombinations(t *testing.T) {
+	// 12 modifiers, and 64 modifiers (the combining diacritics U+0300 to U+033F)
+	var diacritics64 []string
+	for r := '\u0300'; r < '\u0340'; r++ {
+		diacritics64 = append(diacritics64, string(r))
+	}
+	for _, diacritics := range [][]string{
+		{"\u0303", "\u0325", "\u032a", "\u0330", "\u0324", "\u031f", "\u0320", "\u0308", "\u033d", "\u0329", "\u032f", "\u02de"},
+		diacritics64,
+	} {
+		lines := []string{header}
+		for _, v := range "aeiouy" {
+			lines = append(lines, fmt.Sprintf("\t%c\t%c\t%s\tSyllabic", v, v, string2unicode(string(v))))
+		}
+		lines = append(lines, "\t \t\t\tPhonemeDelimiter")
+		for i, d := range diacritics {
+			lines = append(lines, fmt.Sprintf("MODIFIER\t_%d\t%s\tSyllabic", i, d))
+		}
+		_, err := ReadSymbolSet("test", strings.NewReader(strings.Join(lines, "\n")+"\n"))
+		if err == nil || !strings.Contains(err.Error(), "too many combinations") {
+			t.Errorf("%d modifiers: expected error for too many combinations, got %v", len(diacritics), err)
+		}
+	}
+}
+
+func Test_Modifiers_Invalid(t *testing.T) {
+	for _, line := range []string{
+		"MODIFIER	:	ː",
+		"MODIFIER	:	ː	Vowel",
+		"MODIFIER	~	̃	Syllabic", // defined more than once
+		"MODIFIER	;		Syllabic",  // empty ipa
+	} {
+		if _, err := ReadSymbolSet("test", strings.NewReader(modifierTestInput+line+"\n")); err == nil {
+			t.Errorf("expected error for modifier line %s", line)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package symbolset
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// unicode normalization of IPA strings
+
+// NormalizationForm is the Unicode normalization form used for the IPA strings of a symbol set.
+// The IPA strings in the symbol inventory and IPA input transcriptions are normalized to this form before matching, so that precomposed and decomposed characters are treated alike.
+// By default, no normalization is made, and the IPA strings are used as declared. A normalization form can be declared in the .sym file using a UNICODE_NORMALIZATION line, e.g.:
+//
+//	UNICODE_NORMALIZATION	NFD
+type NormalizationForm int
+
+const (
+	// FormNone is used for symbol sets without Unicode normalization (the default)
+	FormNone NormalizationForm = iota
+
+	// FormNFD is canonical decomposition. Since IPA symbols with diacritics are typically decomposed, this is the most robust form for matching.
+	FormNFD
+
+	// FormNFC is canonical decomposition, followed by canonical composition
+	FormNFC
+)
+
+var normalizationFormNames = []string{"None", "NFD", "NFC"}
+
+func (f NormalizationForm) String() string {
+	if f < 0 || int(f) >= len(normalizationFormNames) {
+		return fmt.Sprintf("NormalizationForm(%d)", f)
+	}
+	return normalizationFormNames[f]
+}
+
+// NormalizationFormFromString returns the normalization form with the given name (as returned by NormalizationForm.String). Case is ignored.
+func NormalizationFormFromString(s string) (NormalizationForm, error) {
+	for i, name := range normalizationFormNames {
+		if strings.EqualFold(s, name) {
+			return NormalizationForm(i), nil
+		}
+	}
+	return FormNone, fmt.Errorf("invalid normalization form '%s' (expected one of %s)", s, strings.Join(normalizationFormNames, ", "))
+}
+
+func (f NormalizationForm) normalize(s string) string {
+	switch f {
+	case FormNFD:
+		return norm.NFD.String(s)
+	case FormNFC:
+		return norm.NFC.String(s)
+	}
+	return s
+}
+
+var normalizationDirective = "UNICODE_NORMALIZATION"
+
+func isNormalizationLine(l string) bool {
+	return strings.HasPrefix(l, normalizationDirective+"\t")
+}
+
+func parseNormalizationLine(l string) (NormalizationForm, error) {
+	fs := strings.Split(l, "\t")
+	if len(fs) != 2 {
+		return FormNone, fmt.Errorf("%s line must have 2 fields, found %s", normalizationDirective, l)
+	}
+	return NormalizationFormFromString(strings.TrimSpace(fs[1]))
+}
+
+// ipaEquivalents are classes of characters that are used interchangeably in IPA transcriptions
+var ipaEquivalents = [][]rune{
+	{'⁀', '͡', '‿', '͜'}, // tie bars (character tie, combining double inverted breve, undertie, combining double breve below)
+	{'ɡ', 'g'},           // script g and ASCII g
+}
+
+// buildEquivalentsMap returns a map from characters not used in the IPA inventory, to the equivalent character used in the inventory (if any).
+// Characters declared as IPA aliases are not included, since aliases are resolved (and reported) separately.
+func buildEquivalentsMap(symbols []Symbol, aliases []Alias) map[rune]rune {
+	used := make(map[rune]bool)
+	for _, sym := range symbols {
+		for _, r := range sym.IPA.String {
+			used[r] = true
+		}
+	}
+	aliased := make(map[string]bool)
+	for _, a := range aliases {
+		if a.IPA {
+			aliased[a.Alias] = true
+		}
+	}
+	res := make(map[rune]rune)
+	for _, class := range ipaEquivalents {
+		var target rune
+		for _, r := range class {
+			if used[r] {
+				target = r
+				break
+			}
+		}
+		if target == 0 {
+			continue
+		}
+		for _, r := range class {
+			if !used[r] && !aliased[string(r)] {
+				res[r] = target
+			}
+		}
+	}
+	return res
+}
+
+// normalizeSymbols returns a copy of the symbols, with the IPA strings normalized to the specified form
+func normalizeSymbols(symbols []Symbol, form NormalizationForm) []Symbol {
+	res := make([]Symbol, len(symbols))
+	for i, sym := range symbols {
+		if s := form.normalize(sym.IPA.String); s != sym.IPA.String {
+			sym.IPA = IPASymbol{String: s, Unicode: string2unicode(s)}
+		}
+		res[i] = sym
+	}
+	return res
+}
+
+// normalizeAliases returns a copy of the aliases, with the IPA aliases normalized to the specified form
+func normalizeAliases(aliases []Alias, form NormalizationForm) []Alias {
+	res := make([]Alias, len(aliases))
+	for i, a := range aliases {
+		if a.IPA {
+			a.Alias = form.normalize(a.Alias)
+			a.Canonical = form.normalize(a.Canonical)
+		}
+		res[i] = a
+	}
+	return res
+}
+
+// NormalizeIPAInput normalizes an IPA transcription to the symbol set's normalization form, and replaces characters with their equivalents in the symbol inventory (e.g., tie bars, and g for ɡ).
+// IPA input is normalized automatically by ConvertFromInternalIPA, SplitInternalIPATranscription, GetFromInternalIPA, ValidateInternalIPA, etc.
+func (ss SymbolSet) NormalizeIPAInput(trans string) string {
+	return ss.foldEquivalents(ss.NormalizationForm.normalize(trans))
+}
+
+func (ss SymbolSet) foldEquivalents(s string) string {
+	if len(ss.ipaEquivalents) == 0 {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if eq, ok := ss.ipaEquivalents[r]; ok {
+			return eq
+		}
+		return r
+	}, s)
+}
+
+// normSegment is a segment of an input string, with its byte offsets in the input, and in the normalized string
+type normSegment struct {
+	from, to         int // input offsets
+	normFrom, normTo int // normalized offsets
+}
+
+// normalizeIPAInputWithSegments works like NormalizeIPAInput, but also returns the segments of the input, so that offsets in the normalized string can be mapped back to the input
+func (ss SymbolSet) normalizeIPAInputWithSegments(trans string) (string, []normSegment) {
+	var res strings.Builder
+	var segments []normSegment
+	add := func(from, to int, normalized string) {
+		normalized = ss.foldEquivalents(normalized)
+		segments = append(segments, normSegment{from: from, to: to, normFrom: res.Len(), normTo: res.Len() + len(normalized)})
+		res.WriteString(normalized)
+	}
+	switch ss.NormalizationForm {
+	case FormNFD, FormNFC:
+		f := norm.NFD
+		if ss.NormalizationForm == FormNFC {
+			f = norm.NFC
+		}
+		var it norm.Iter
+		it.InitString(f, trans)
+		for !it.Done() {
+			from := it.Pos()
+			seg := string(it.Next())
+			add(from, it.Pos(), seg)
+		}
+	default:
+		for i, r := range trans {
+			add(i, i+utf8.RuneLen(r), string(r))
+		}
+	}
+	return res.String(), segments
+}
+
+// inputSpan maps a span of the normalized string to the corresponding span of the input (expanded to segment boundaries)
+func inputSpan(segments []normSegment, normFrom, normTo int) (int, int) {
+	if len(segments) == 0 {
+		return 0, 0
+	}
+	last := segments[len(segments)-1]
+	from, to := last.to, last.to
+	for _, seg := range segments {
+		if normFrom < seg.normTo {
+			from = seg.from
+			break
+		}
+	}
+	if normTo <= normFrom {
+		return from, from
+	}
+	for _, seg := range segments {
+		if normTo <= seg.normTo {
+			to = seg.to
+			break
+		}
+	}
+	return from, to
+}
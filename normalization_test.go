@@ -0,0 +1,207 @@
+package symbolset
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var normalizationTestInput = `DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY
+	a	a	U+0061	Syllabic
+	e	e	U+0065	Syllabic
+	C	ç	U+00E7	NonSyllabic
+	ts	t͡s	U+0074U+0361U+0073	NonSyllabic
+	g	ɡ	U+0261	NonSyllabic
+	"	ˈ	U+02C8	Stress
+	""	ˈ̀	U+02C8U+0300	Stress
+	.	.	U+002E	SyllableDelimiter
+	 			PhonemeDelimiter
+`
+
+func Test_Normalization_ConvertFromInternalIPA(t *testing.T) {
+	nfd, err := ReadSymbolSet("nfd", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNFD\n"))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	nfc, err := ReadSymbolSet("nfc", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNFC\n"))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if nfd.NormalizationForm != FormNFD || nfc.NormalizationForm != FormNFC {
+		t.Fatalf("unexpected normalization forms: %v, %v", nfd.NormalizationForm, nfc.NormalizationForm)
+	}
+
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{"ˈa.\u00e7a", `" a . C a`},             // precomposed ç
+		{"ˈa.c\u0327a", `" a . C a`},            // decomposed ç
+		{"\u02c8\u0300a.\u00e7a", `"" a . C a`}, // accent II
+		{"t\u0361sa", "ts a"},
+		{"t\u2040sa", "ts a"}, // character tie
+		{"t\u035csa", "ts a"}, // double breve below
+		{"ga", "g a"},         // ASCII g
+		{"\u0261a", "g a"},
+	}
+	for _, ss := range []SymbolSet{nfd, nfc} {
+		for _, test := range tests {
+			result, err := ss.ConvertFromInternalIPA(test.input)
+			if err != nil {
+				t.Errorf("%s /%s/: didn't expect error here : %v", ss.Name, test.input, err)
+				continue
+			}
+			if result != test.expect {
+				t.Errorf("%s /%s/: "+fsExp, ss.Name, test.input, test.expect, result)
+			}
+		}
+	}
+}
+
+func Test_Normalization_Inventory(t *testing.T) {
+	// by default, the IPA strings are used as declared
+	def, err := ReadSymbolSet("default", strings.NewReader(normalizationTestInput))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if def.NormalizationForm != FormNone {
+		t.Errorf(fsExp, FormNone, def.NormalizationForm)
+	}
+	sym, err := def.Get("C")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if sym.IPA.String != "\u00e7" || sym.IPA.Unicode != "U+00E7" {
+		t.Errorf(fsExp, "U+00E7", sym.IPA.Unicode)
+	}
+	res, err := def.ConvertToInternalIPA(`" a . C a`)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if res != "\u02c8a.\u00e7a" {
+		t.Errorf(fsExp, "\u02c8a.\u00e7a", res)
+	}
+	var buf bytes.Buffer
+	if err := def.WriteSym(&buf); err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if buf.String() != normalizationTestInput {
+		t.Errorf(fsExp, normalizationTestInput, buf.String())
+	}
+
+	nfd, err := ReadSymbolSet("nfd", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNFD\n"))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	sym, err = nfd.Get("C")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if sym.IPA.String != "c\u0327" || sym.IPA.Unicode != "U+0063U+0327" {
+		t.Errorf(fsExp, "U+0063U+0327", sym.IPA.Unicode)
+	}
+	res, err = nfd.ConvertToInternalIPA(`" a . C a`)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if res != "\u02c8a.c\u0327a" {
+		t.Errorf(fsExp, "\u02c8a.c\u0327a", res)
+	}
+
+	none, err := ReadSymbolSet("none", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNone\n"))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if !none.ValidInternalIPASymbol("\u00e7") || none.ValidInternalIPASymbol("c\u0327") {
+		t.Errorf("expected only precomposed ç to be valid without normalization")
+	}
+}
+
+func Test_Normalization_Equivalents(t *testing.T) {
+	// both tie bars are used in the inventory, so neither is folded into the other (other tie bars are folded into the first one)
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "ts", Cat: NonSyllabic, IPA: IPASymbol{String: "t\u0361s", Unicode: "U+0074U+0361U+0073"}},
+		{String: "t_s", Cat: NonSyllabic, IPA: IPASymbol{String: "t\u2040s", Unicode: "U+0074U+2040U+0073"}},
+		{String: " ", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	ss, err := NewSymbolSet("test", symbols)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	for input, expect := range map[string]string{"t\u0361sa": "ts a", "t\u2040sa": "t_s a", "t\u203fsa": "t_s a"} {
+		result, err := ss.ConvertFromInternalIPA(input)
+		if err != nil {
+			t.Errorf("/%s/: didn't expect error here : %v", input, err)
+			continue
+		}
+		if result != expect {
+			t.Errorf("/%s/: "+fsExp, input, expect, result)
+		}
+	}
+}
+
+func Test_Normalization_ValidatePositions(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNFD\n"))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	// the precomposed ç is decomposed before validation, but positions refer to the input
+	diags := ss.ValidateInternalIPA("ˈ\u00e7a.\u00e7x")
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, found %v", diags)
+	}
+	d := diags[0]
+	if d.Token != "x" || d.ByteOffset != 8 || d.RuneOffset != 5 {
+		t.Errorf("unexpected diagnostic %#v", d)
+	}
+}
+
+func Test_Normalization_WriteSym(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNFC\n"))
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var buf bytes.Buffer
+	if err := ss.WriteSym(&buf); err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if !strings.Contains(buf.String(), "UNICODE_NORMALIZATION\tNFC\n") {
+		t.Errorf("expected normalization directive in output, found\n%s", buf.String())
+	}
+	reloaded, err := ReadSymbolSet("test", &buf)
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if reloaded.NormalizationForm != FormNFC {
+		t.Errorf(fsExp, FormNFC, reloaded.NormalizationForm)
+	}
+
+	fromJSON, err := NewSymbolSetFromJSON(ss.ToJSON())
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if fromJSON.NormalizationForm != FormNFC {
+		t.Errorf(fsExp, FormNFC, fromJSON.NormalizationForm)
+	}
+
+	// a declared line is kept, also for the default form
+	for _, form := range []string{"NFD", "None"} {
+		input := normalizationTestInput + "UNICODE_NORMALIZATION\t" + form + "\n"
+		ss, err := ReadSymbolSet("test", strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("didn't expect error here : %v", err)
+		}
+		buf.Reset()
+		if err := ss.WriteSym(&buf); err != nil {
+			t.Fatalf("didn't expect error here : %v", err)
+		}
+		if !strings.Contains(buf.String(), "UNICODE_NORMALIZATION\t"+form+"\n") {
+			t.Errorf("expected normalization directive for %s in output, found\n%s", form, buf.String())
+		}
+	}
+
+	if _, err := ReadSymbolSet("test", strings.NewReader(normalizationTestInput+"UNICODE_NORMALIZATION\tNFKC\n")); err == nil {
+		t.Errorf("expected error for invalid normalization form")
+	}
+}
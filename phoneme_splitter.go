@@ -22,6 +22,7 @@ func (s byLength) Less(i, j int) bool {
 	return len(s[i]) > len(s[j])
 }
 
+// splitIntoPhonemes is the original recursive splitter. SymbolSet now uses a pre-computed trie instead (see trie.go), but this implementation is kept as a reference for tests and benchmarks.
 func splitIntoPhonemes(knownPhonemes []Symbol, transcription string) (phonemes []string, unknown []string, error error) {
 
 	var known []string
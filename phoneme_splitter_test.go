@@ -29,12 +29,12 @@ func Test_LenSort(t *testing.T) {
 
 func Test_splitIntoPhonemes(t *testing.T) {
 	phs := []Symbol{
-		{"aa", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"a", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"bb", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"b", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"ddddd", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"f33", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
+		{String: "aa", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "a", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "bb", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "ddddd", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "f33", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
 	}
 	s1 := "c"
 	res, unk, err := splitIntoPhonemes(phs, s1)
@@ -97,9 +97,9 @@ func Test_splitIntoPhonemes(t *testing.T) {
 
 func Test_splitIntoPhonemes2(t *testing.T) {
 	phs1 := []Symbol{
-		{"aa", Syllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"b", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{" ", "n/a"}},
+		{String: "aa", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: " ", Unicode: "n/a"}},
 	}
 
 	// _
@@ -110,9 +110,9 @@ func Test_splitIntoPhonemes2(t *testing.T) {
 	}
 
 	phs2 := []Symbol{
-		{"aa", Syllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"b", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"", PhonemeDelimiter, "", IPASymbol{" ", "n/a"}},
+		{String: "aa", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: " ", Unicode: "n/a"}},
 	}
 
 	// _
@@ -125,15 +125,15 @@ func Test_splitIntoPhonemes2(t *testing.T) {
 
 func Test_splitIntoPhonemesIPA(t *testing.T) {
 	phs1 := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", ""}},
-		{"b", NonSyllabic, "", IPASymbol{"b", ""}},
-		{"r", NonSyllabic, "", IPASymbol{"r", ""}},
-		{"k", NonSyllabic, "", IPASymbol{"k", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"ɑː", Syllabic, "", IPASymbol{"ɑː", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{".", ""}},
-		{"\u02C8", Stress, "", IPASymbol{"\u02C8", ""}},
-		{"\u02C8\u0300", Stress, "", IPASymbol{"\u02C8\u0300", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: ""}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: ""}},
+		{String: "r", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "r", Unicode: ""}},
+		{String: "k", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "k", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "ɑː", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "ɑː", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: ""}},
+		{String: "\u02C8", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8", Unicode: ""}},
+		{String: "\u02C8\u0300", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8\u0300", Unicode: ""}},
 	}
 
 	// _
@@ -144,9 +144,9 @@ func Test_splitIntoPhonemesIPA(t *testing.T) {
 	}
 
 	phs2 := []Symbol{
-		{"aa", Syllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"b", NonSyllabic, "", IPASymbol{"n/a", "n/a"}},
-		{"", PhonemeDelimiter, "", IPASymbol{" ", "n/a"}},
+		{String: "aa", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "n/a", Unicode: "n/a"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: " ", Unicode: "n/a"}},
 	}
 
 	// _
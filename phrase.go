@@ -0,0 +1,120 @@
+package symbolset
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// splitting of phrase transcriptions into words
+
+// Phrase is a transcription split into words, at word delimiters, pauses and intonation boundaries
+type Phrase struct {
+	// Words are the symbols of each word in the phrase
+	Words [][]string
+
+	// Boundaries are the word delimiters, pauses and intonation boundaries between the words: Boundaries[i] precedes Words[i], and the last element follows the last word.
+	// There is always one more element in Boundaries than in Words.
+	Boundaries [][]string
+}
+
+// isWordBoundary returns true for symbol categories that separate words in a phrase
+func isWordBoundary(cat SymbolCat) bool {
+	return cat == WordDelimiter || isProsodicBoundary(cat)
+}
+
+// splitPhrase groups the symbols into words, using isBoundary to identify the word boundaries
+func splitPhrase(symbols []string, isBoundary func(string) bool) Phrase {
+	res := Phrase{Boundaries: [][]string{{}}}
+	var word []string
+	for _, sym := range symbols {
+		if !isBoundary(sym) {
+			word = append(word, sym)
+			continue
+		}
+		if len(word) > 0 {
+			res.Words = append(res.Words, word)
+			res.Boundaries = append(res.Boundaries, []string{})
+			word = nil
+		}
+		last := len(res.Boundaries) - 1
+		res.Boundaries[last] = append(res.Boundaries[last], sym)
+	}
+	if len(word) > 0 {
+		res.Words = append(res.Words, word)
+		res.Boundaries = append(res.Boundaries, []string{})
+	}
+	return res
+}
+
+// SplitPhrase splits the input transcription into words, at word delimiters, pauses and intonation boundaries
+func (ss SymbolSet) SplitPhrase(trans string) (Phrase, error) {
+	splitted, err := ss.SplitTranscription(trans)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return splitPhrase(splitted, func(s string) bool {
+		sym, err := ss.Get(s)
+		return err == nil && isWordBoundary(sym.Cat)
+	}), nil
+}
+
+// SplitWords splits the input transcription into words, at word delimiters, pauses and intonation boundaries. The boundary symbols are not included in the result.
+func (ss SymbolSet) SplitWords(trans string) ([]string, error) {
+	phrase, err := ss.SplitPhrase(trans)
+	if err != nil {
+		return []string{}, err
+	}
+	res := []string{}
+	for _, w := range phrase.Words {
+		res = append(res, strings.Join(w, ss.PhonemeDelimiter.String))
+	}
+	return res, nil
+}
+
+// mapPhrase maps each word in the phrase using mapWord, and each boundary symbol using mapBoundary, and joins the result using the delimiter.
+// Unknown input symbols are collected from all words.
+func mapPhrase(phrase Phrase, delimiter string, mapWord func([]string) (string, error), mapBoundary func(string) (string, error)) (string, error) {
+	var unknown []string
+	var res []string
+	appendBoundaries := func(boundaries []string) error {
+		for _, b := range boundaries {
+			s, err := mapBoundary(b)
+			if err != nil {
+				return err
+			}
+			if len(s) > 0 {
+				res = append(res, s)
+			}
+		}
+		return nil
+	}
+	for i, word := range phrase.Words {
+		if err := appendBoundaries(phrase.Boundaries[i]); err != nil {
+			return "", err
+		}
+		s, err := mapWord(word)
+		var ssErr *SymbolSetError
+		if errors.As(err, &ssErr) && ssErr.ErrorCode == ErrCodeUnknownInputSymbol {
+			for _, v := range ssErr.Values {
+				if !slices.Contains(unknown, v) {
+					unknown = append(unknown, v)
+				}
+			}
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if len(s) > 0 {
+			res = append(res, s)
+		}
+	}
+	if err := appendBoundaries(phrase.Boundaries[len(phrase.Words)]); err != nil {
+		return "", err
+	}
+	if len(unknown) > 0 {
+		return "", UnknownInputSymbol(unknown)
+	}
+	return strings.Join(res, delimiter), nil
+}
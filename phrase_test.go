@@ -0,0 +1,85 @@
+package symbolset
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_SplitWords(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/cmn_x-sampa-tone.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var tests = []struct {
+		input  string
+		expect []string
+	}{
+		{"p _h a _1 . m a _4", []string{"p _h a _1 . m a _4"}},
+		{"m a _1 # n i _3", []string{"m a _1", "n i _3"}},
+		{"m a _1 sil n i _3 || l a : _4", []string{"m a _1", "n i _3", "l a : _4"}},
+		{"# m a _1 # sil # n i _3 #", []string{"m a _1", "n i _3"}},
+		{"sil", []string{}},
+	}
+	for _, test := range tests {
+		result, err := ss.SplitWords(test.input)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if strings.Join(result, ";") != strings.Join(test.expect, ";") || len(result) != len(test.expect) {
+			t.Errorf("/%s/: "+fsExp, test.input, test.expect, result)
+		}
+	}
+
+	phrase, err := ss.SplitPhrase("# m a _1 # sil # n i _3")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	if len(phrase.Boundaries) != 3 || strings.Join(phrase.Boundaries[0], " ") != "#" || strings.Join(phrase.Boundaries[1], " ") != "# sil #" || len(phrase.Boundaries[2]) != 0 {
+		t.Errorf("unexpected boundaries: %v", phrase.Boundaries)
+	}
+}
+
+func Test_ConvertPhrase(t *testing.T) {
+	wordDelim := Symbol{String: "#", Cat: WordDelimiter, IPA: IPASymbol{String: "‿", Unicode: "U+203F"}}
+	pause := Symbol{String: "_", Cat: Pause, IPA: IPASymbol{String: "(.)", Unicode: "U+0028U+002EU+0029"}}
+	var tests = []struct {
+		placement StressPlacement
+		stress    string
+		input     string
+		ipa       string
+	}{
+		// stress is placed within each word
+		{StressToneLetter, "1", "p l a # t e 1", "pla‿ˈte"},
+		{StressToneLetter, "1", "p l a 1 # t e", "ˈpla‿te"},
+		{StressBeforeNucleus, "'", "p l ' a _ t e", "ˈpla(.)te"},
+		{StressAfterNucleus, "1", "p l a # m a . t e1", "pla‿ma.ˈte"},
+		{StressBeforeSyllable, "'", "' p l a # t e _ m a", "ˈpla‿te(.)ma"},
+	}
+	for _, test := range tests {
+		ss := stressPlacementTestSet(t, "test", test.placement, test.stress, wordDelim, pause)
+		result, err := ss.ConvertToInternalIPA(test.input)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != test.ipa {
+			t.Errorf("%v /%s/: "+fsExp, test.placement, test.input, test.ipa, result)
+		}
+		result, err = ss.ConvertFromInternalIPA(test.ipa)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != test.input {
+			t.Errorf("%v /%s/: "+fsExp, test.placement, test.ipa, test.input, result)
+		}
+	}
+
+	// unknown symbols are reported for all words
+	ss := stressPlacementTestSet(t, "test", StressBeforeSyllable, "'", wordDelim)
+	_, err := ss.ConvertToInternalIPA("p x # t y")
+	if err == nil || !strings.Contains(err.Error(), "[x y]") {
+		t.Errorf("expected unknown input symbols x and y, got %v", err)
+	}
+}
@@ -14,11 +14,12 @@ type MapRequest struct {
 
 // MapError : container
 type MapError struct {
-	Type      string     `json:"type"`       // error/result
-	ErrorType string     `json:"error_type"` // examples: unknown phoneme(s), etc
-	ErrorCode int        `json:"error_code"` //
-	Values    []string   `json:"values"`
-	Request   MapRequest `json:"request"`
+	Type        string       `json:"type"`       // error/result
+	ErrorType   string       `json:"error_type"` // examples: unknown phoneme(s), etc
+	ErrorCode   int          `json:"error_code"` //
+	Values      []string     `json:"values"`
+	Request     MapRequest   `json:"request"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"` // positions of the errors in the input transcription
 }
 
 func UnknownMapError() MapError {
@@ -33,6 +34,17 @@ const (
 	ErrCodeUnknownInputSymbol = 25
 	ErrCodeUnknownSymbolType  = 26
 	ErrCodeUnknownSymbolSet   = 27
+	ErrCodeLint               = 28
+	ErrCodeMisplacedStress    = 29
+	ErrCodeEmptySyllable      = 30
+	ErrCodeDeprecatedSymbol   = 31
+	ErrCodeSyllableNucleus    = 32
+	ErrCodeMultiplePrimary    = 33
+	ErrCodeDoubleDelimiter    = 34
+	ErrCodeCompoundInSyllable = 35
+	ErrCodeMissingCompound    = 36
+	ErrCodeExtraCompound      = 37
+	ErrCodeMisalignedCompound = 38
 )
 
 // SymbolSetError : container
@@ -70,6 +82,126 @@ func UnknownSymbolSet(values []string) *SymbolSetError {
 	}
 }
 
+func LintError(values []string) *SymbolSetError {
+	return &SymbolSetError{
+		ErrorType: "Lint error",
+		ErrorCode: ErrCodeLint,
+		Values:    values,
+	}
+}
+
+func UnknownSymbolDiagnostic(token string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Unknown input symbol",
+		ErrorCode: ErrCodeUnknownInputSymbol,
+		Message:   fmt.Sprintf("unknown symbol '%s'", token),
+	}
+}
+
+func MisplacedStressDiagnostic(token string, afterNucleus bool) Diagnostic {
+	msg := fmt.Sprintf("stress '%s' is not followed by a syllabic phoneme", token)
+	if afterNucleus {
+		msg = fmt.Sprintf("stress '%s' is not preceded by a syllabic phoneme", token)
+	}
+	return Diagnostic{
+		ErrorType: "Misplaced stress",
+		ErrorCode: ErrCodeMisplacedStress,
+		Message:   msg,
+	}
+}
+
+func EmptySyllableDiagnostic(token string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Empty syllable",
+		ErrorCode: ErrCodeEmptySyllable,
+		Message:   fmt.Sprintf("empty syllable at delimiter '%s'", token),
+	}
+}
+
+func DeprecatedSymbolDiagnostic(token string, canonical string) Diagnostic {
+	return Diagnostic{
+		ErrorType:  "Deprecated symbol",
+		ErrorCode:  ErrCodeDeprecatedSymbol,
+		Message:    fmt.Sprintf("deprecated symbol '%s'", token),
+		Suggestion: canonical,
+	}
+}
+
+func StressPlacementDiagnostic(token string, placement StressPlacement) Diagnostic {
+	msg := fmt.Sprintf("stress '%s' is not placed at the start of the syllable", token)
+	switch placement {
+	case StressBeforeNucleus:
+		msg = fmt.Sprintf("stress '%s' is not placed before the syllabic nucleus", token)
+	case StressAfterNucleus:
+		msg = fmt.Sprintf("stress '%s' is not placed after the syllabic nucleus", token)
+	case StressToneLetter:
+		msg = fmt.Sprintf("stress '%s' is not placed at the end of the syllable", token)
+	case StressUndefined:
+		msg = fmt.Sprintf("stress '%s' is not placed at the start of the syllable, or before the syllabic nucleus", token)
+	}
+	return Diagnostic{
+		ErrorType: "Misplaced stress",
+		ErrorCode: ErrCodeMisplacedStress,
+		Message:   msg,
+	}
+}
+
+func SyllableNucleusDiagnostic(nNuclei int) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Syllable nucleus",
+		ErrorCode: ErrCodeSyllableNucleus,
+		Message:   fmt.Sprintf("syllable should have exactly one syllabic nucleus, found %d", nNuclei),
+	}
+}
+
+func MultiplePrimaryStressDiagnostic(token string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Multiple primary stress",
+		ErrorCode: ErrCodeMultiplePrimary,
+		Message:   fmt.Sprintf("word has more than one primary stress, found '%s'", token),
+	}
+}
+
+func DoubleDelimiterDiagnostic(token string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Double delimiter",
+		ErrorCode: ErrCodeDoubleDelimiter,
+		Message:   fmt.Sprintf("doubled delimiter '%s'", token),
+	}
+}
+
+func CompoundInsideSyllableDiagnostic(token string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Compound delimiter inside syllable",
+		ErrorCode: ErrCodeCompoundInSyllable,
+		Message:   fmt.Sprintf("compound delimiter '%s' is placed inside a syllable", token),
+	}
+}
+
+func MissingCompoundDelimiterDiagnostic(before string, after string, expected string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Missing compound delimiter",
+		ErrorCode: ErrCodeMissingCompound,
+		Message:   fmt.Sprintf("no compound delimiter for the compound boundary between '%s' and '%s', %s", before, after, expected),
+	}
+}
+
+func ExtraCompoundDelimiterDiagnostic(token string, decompounded string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Extra compound delimiter",
+		ErrorCode: ErrCodeExtraCompound,
+		Message:   fmt.Sprintf("compound delimiter '%s' has no matching compound boundary in '%s'", token, decompounded),
+	}
+}
+
+func MisalignedCompoundDelimiterDiagnostic(token string, before string, after string, expected string) Diagnostic {
+	return Diagnostic{
+		ErrorType: "Misaligned compound delimiter",
+		ErrorCode: ErrCodeMisalignedCompound,
+		Message:   fmt.Sprintf("compound delimiter '%s' is not aligned with the compound boundary between '%s' and '%s', %s", token, before, after, expected),
+	}
+}
+
 func (ss SymbolSetError) String() string {
 	return fmt.Sprintf("[%s]: %s", ss.ErrorType, strings.Join(ss.Values, ", "))
 }
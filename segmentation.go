@@ -0,0 +1,156 @@
+package symbolset
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ambiguous segmentation of transcriptions without phoneme delimiters
+
+// Ambiguity is an input string that can be split into symbols in more than one way
+type Ambiguity struct {
+	Input         string
+	Segmentations [][]string
+}
+
+func (a Ambiguity) String() string {
+	var segs []string
+	for _, seg := range a.Segmentations {
+		segs = append(segs, strings.Join(seg, " "))
+	}
+	return fmt.Sprintf("/%s/ => %s", a.Input, strings.Join(segs, " | "))
+}
+
+// Segmentations returns all possible ways to split the input transcription into symbols.
+// For symbol sets with a non-empty phoneme delimiter, there is only one possible segmentation.
+// For symbol sets without phoneme delimiter, every valid segmentation is returned, longest match first (i.e., if SplitTranscription finds a valid segmentation, it is the first one).
+// Input that cannot be split using longest match may still have valid segmentations (e.g., a+bc for /abc/, with the symbols a, ab and bc). An unknown input symbol error is returned only if there is no valid segmentation.
+func (ss SymbolSet) Segmentations(trans string) ([][]string, error) {
+	if !ss.isInit {
+		panic("symbolSet " + ss.Name + " has not been initialized properly!")
+	}
+	if ss.phonemeDelimiterRe.FindStringIndex("") == nil {
+		splitted, err := ss.SplitTranscription(trans)
+		if err != nil {
+			return [][]string{}, err
+		}
+		return [][]string{splitted}, nil
+	}
+	res := ss.symbolTrie.segmentations(trans)
+	if len(res) == 0 {
+		// a complete segmentation would also have been found by the trie, so the input has unknown symbols
+		if _, err := ss.SplitTranscription(trans); err != nil {
+			return [][]string{}, err
+		}
+		return [][]string{}, fmt.Errorf("no valid segmentation for /%s/ in symbol set %s", trans, ss.Name)
+	}
+	return res, nil
+}
+
+// IsAmbiguous checks if the input transcription has more than one possible segmentation
+func (ss SymbolSet) IsAmbiguous(trans string) (bool, error) {
+	segs, err := ss.Segmentations(trans)
+	if err != nil {
+		return false, err
+	}
+	return len(segs) > 1, nil
+}
+
+// Ambiguities returns the ambiguities found in the symbol inventory when the symbol set was loaded.
+// An ambiguity is an input string that can be split into symbols in more than one way.
+// Only symbol sets without phoneme delimiter can have ambiguities.
+func (ss SymbolSet) Ambiguities() []Ambiguity {
+	return ss.ambiguities
+}
+
+// segmentations returns all segmentations of trans into symbols in the trie, longest match first
+func (t *trie) segmentations(trans string) [][]string {
+	// dead[i] is true if there is no valid segmentation of trans[i:]
+	dead := make(map[int]bool)
+	var res [][]string
+	var rec func(pos int, acc []string) bool
+	rec = func(pos int, acc []string) bool {
+		if pos == len(trans) {
+			res = append(res, slices.Clone(acc))
+			return true
+		}
+		if dead[pos] {
+			return false
+		}
+		found := false
+		ms := t.matches(trans[pos:])
+		for i := len(ms) - 1; i >= 0; i-- {
+			n := ms[i]
+			if rec(pos+n, append(acc, trans[pos:pos+n])) {
+				found = true
+			}
+		}
+		if !found {
+			dead[pos] = true
+		}
+		return found
+	}
+	rec(0, []string{})
+	return res
+}
+
+// findAmbiguities checks if the symbols form a uniquely decodable code, using the Sardinas-Patterson algorithm.
+// For each pair of symbols where one is a prefix of the other, the shortest ambiguous input string (if any) is returned.
+func findAmbiguities(symbols []string) []Ambiguity {
+	type state struct {
+		dangling string
+		behind   []string // the segmentation that is one dangling suffix shorter than ahead
+		ahead    []string
+	}
+
+	var nonEmpty []string
+	for _, s := range symbols {
+		if len(s) > 0 && !slices.Contains(nonEmpty, s) {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	slices.Sort(nonEmpty)
+
+	var res []Ambiguity
+	seen := make(map[string]bool)
+	for _, a := range nonEmpty {
+		for _, b := range nonEmpty {
+			if a == b || !strings.HasPrefix(b, a) {
+				continue
+			}
+			visited := map[string]bool{}
+			queue := []state{{dangling: b[len(a):], behind: []string{a}, ahead: []string{b}}}
+		search:
+			for len(queue) > 0 {
+				st := queue[0]
+				queue = queue[1:]
+				if visited[st.dangling] {
+					continue
+				}
+				visited[st.dangling] = true
+				for _, s := range nonEmpty {
+					behind := append(slices.Clone(st.behind), s)
+					switch {
+					case s == st.dangling:
+						input := strings.Join(st.ahead, "")
+						if !seen[input] {
+							seen[input] = true
+							segs := [][]string{behind, st.ahead}
+							if len(st.ahead) < len(behind) {
+								segs = [][]string{st.ahead, behind}
+							}
+							res = append(res, Ambiguity{Input: input, Segmentations: segs})
+						}
+						break search
+					case strings.HasPrefix(st.dangling, s):
+						queue = append(queue, state{dangling: st.dangling[len(s):], behind: behind, ahead: st.ahead})
+					case strings.HasPrefix(s, st.dangling):
+						queue = append(queue, state{dangling: s[len(st.dangling):], behind: st.ahead, ahead: behind})
+					}
+				}
+			}
+		}
+	}
+	return res
+}
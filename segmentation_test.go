@@ -0,0 +1,146 @@
+package symbolset
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_Segmentations(t *testing.T) {
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "u", Cat: Syllabic, IPA: IPASymbol{String: "u", Unicode: "U+0075"}},
+		{String: "au", Cat: Syllabic, IPA: IPASymbol{String: "au", Unicode: "U+0061U+0075"}},
+		{String: "t", Cat: NonSyllabic, IPA: IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: "\"", Cat: Stress, IPA: IPASymbol{String: "ˈ", Unicode: "U+02C8"}},
+		{String: "\"\"", Cat: Stress, IPA: IPASymbol{String: "ˈ̀", Unicode: "U+02C8U+0300"}},
+		{String: "", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	ss, err := NewSymbolSet("test", symbols)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+
+	var tests = []struct {
+		input  string
+		expect [][]string
+	}{
+		{"tat", [][]string{{"t", "a", "t"}}},
+		{"tau", [][]string{{"t", "au"}, {"t", "a", "u"}}},
+		{"\"\"tau", [][]string{
+			{"\"\"", "t", "au"},
+			{"\"\"", "t", "a", "u"},
+			{"\"", "\"", "t", "au"},
+			{"\"", "\"", "t", "a", "u"},
+		}},
+	}
+	for _, test := range tests {
+		result, err := ss.Segmentations(test.input)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expect) {
+			t.Errorf("Segmentations(%s): "+fsExp, test.input, test.expect, result)
+		}
+		ambig, _ := ss.IsAmbiguous(test.input)
+		if ambig != (len(test.expect) > 1) {
+			t.Errorf("IsAmbiguous(%s): "+fsExp, test.input, len(test.expect) > 1, ambig)
+		}
+	}
+
+	if _, err := ss.Segmentations("tax"); err == nil {
+		t.Errorf("expected error for unknown symbol")
+	}
+}
+
+func Test_Segmentations_LongestMatchFails(t *testing.T) {
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "ab", Cat: Syllabic, IPA: IPASymbol{String: "æ", Unicode: "U+00E6"}},
+		{String: "bc", Cat: NonSyllabic, IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	ss, err := NewSymbolSet("test", symbols)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	// longest match splits ab + c, which fails
+	result, err := ss.Segmentations("abc")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if expect := [][]string{{"a", "bc"}}; !reflect.DeepEqual(result, expect) {
+		t.Errorf(fsExp, expect, result)
+	}
+
+	_, err = ss.Segmentations("abd")
+	var ssErr *SymbolSetError
+	if !errors.As(err, &ssErr) || ssErr.ErrorCode != ErrCodeUnknownInputSymbol {
+		t.Errorf("expected unknown input symbol error, got %v", err)
+	}
+}
+
+func Test_Segmentations_WithDelimiter(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	result, err := ss.Segmentations("\" b a . k a")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	expect := [][]string{{"\"", "b", "a", ".", "k", "a"}}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf(fsExp, expect, result)
+	}
+	if len(ss.Ambiguities()) > 0 {
+		t.Errorf("expected no ambiguities for %s, found %v", ss.Name, ss.Ambiguities())
+	}
+}
+
+func Test_findAmbiguities(t *testing.T) {
+	var tests = []struct {
+		symbols []string
+		expect  []Ambiguity
+	}{
+		{[]string{"a", "t", "t_s", "s"}, nil},
+		{[]string{"a", "u", "au"}, []Ambiguity{
+			{Input: "au", Segmentations: [][]string{{"au"}, {"a", "u"}}},
+		}},
+		{[]string{"\"", "\"\"", "a"}, []Ambiguity{
+			{Input: "\"\"", Segmentations: [][]string{{"\"\""}, {"\"", "\""}}},
+		}},
+		// no symbol can be split into other symbols, but "abc" can be split in two ways
+		{[]string{"a", "ab", "bc", "c"}, []Ambiguity{
+			{Input: "abc", Segmentations: [][]string{{"ab", "c"}, {"a", "bc"}}},
+		}},
+	}
+	for _, test := range tests {
+		result := findAmbiguities(test.symbols)
+		if !reflect.DeepEqual(result, test.expect) {
+			t.Errorf("findAmbiguities(%v): "+fsExp, test.symbols, test.expect, result)
+		}
+	}
+}
+
+func Test_Ambiguities_FromFile(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	found := false
+	for _, amb := range ss.Ambiguities() {
+		if amb.Input == "\"\"" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected ambiguity for /\"\"/ in %s, found %v", ss.Name, ss.Ambiguities())
+	}
+}
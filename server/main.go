@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"log/syslog"
 	"net/http"
@@ -18,6 +17,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/stts-se/symbolset/ipa"
 )
 
 // GLOBAL FLAGS
@@ -68,6 +68,11 @@ func indexHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, html)
 }
 
+func ipaTableHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, ipa.Table)
+}
+
 func isStaticPage(url string) bool {
 	return url == "/" || strings.Contains(url, "externals") || strings.Contains(url, "built") || url == "/websockreg" || url == "/favicon.ico" || url == "/static/" || url == "/ipa_table" || url == "/ping" || url == "/version"
 }
@@ -134,7 +139,7 @@ func generateAbout(w http.ResponseWriter, r *http.Request) {
 	if _, err := os.Stat(buildInfoFile); os.IsNotExist(err) {
 		log.Printf("no build info file, will generate about info on-the-fly")
 	} else {
-		bytes, err := ioutil.ReadFile(filepath.Clean(buildInfoFile))
+		bytes, err := os.ReadFile(filepath.Clean(buildInfoFile))
 		if err != nil {
 			log.Printf("failed loading buildinfo file : %v", err)
 		}
@@ -290,16 +295,16 @@ func main() {
 	mapper.addHandler(mapperList)
 	mapper.addHandler(mapperMap)
 	mapper.addHandler(mapperMaptable)
+	mapper.addHandler(mapperImportIPA)
+	mapper.addHandler(mapperImportIPAQuery)
 
 	converter := newSubRouter(rout, "/converter", "Convert transcriptions between languages")
 	converter.addHandler(converterConvert)
 	converter.addHandler(converterList)
 	converter.addHandler(converterTable)
 
-	// static
-	rout.HandleFunc("/ipa_table", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, filepath.Join(staticFolder, "ipa_table.txt"))
-	})
+	// the IPA table of the ipa package
+	rout.HandleFunc("/ipa_table", ipaTableHandler)
 
 	var urls = []string{}
 	errW := rout.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
@@ -324,12 +329,14 @@ func main() {
 	meta.addHandler(metaURLsHandler(urls))
 	meta.addHandler(metaExamplesHandler)
 
+	// the IPA table used to be served from the static folder
+	rout.HandleFunc("/static/ipa_table.txt", ipaTableHandler)
 	if _, err := os.Stat(staticFolder); os.IsNotExist(err) {
-		log.Fatalf("Static folder does not exist: %s", staticFolder)
+		log.Printf("Static folder does not exist: %s", staticFolder)
+	} else {
+		rout.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir(staticFolder))))
 	}
 
-	rout.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir(staticFolder))))
-
 	// static
 	rout.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, filepath.Join(staticFolder, "favicon.ico"))
@@ -85,13 +85,19 @@ var mapperMap = urlHandler{
 		var sse *symbolset.SymbolSetError
 		if err != nil {
 			if errors.As(err, &sse) {
-				mapErrors = append(mapErrors, symbolset.MapError{
+				mapError := symbolset.MapError{
 					Type:      "error",
 					ErrorType: sse.ErrorType,
 					ErrorCode: sse.ErrorCode,
 					Values:    sse.Values,
 					Request:   mapRequest,
-				})
+				}
+				if sse.ErrorCode == symbolset.ErrCodeUnknownInputSymbol {
+					mMut.Lock()
+					mapError.Diagnostics, _ = mMut.service.InputDiagnostics(fromName, toName, trans)
+					mMut.Unlock()
+				}
+				mapErrors = append(mapErrors, mapError)
 			} else {
 				msg := fmt.Sprintf("server error : %v", err)
 				log.Println(msg)
@@ -126,6 +132,70 @@ var mapperMap = urlHandler{
 	},
 }
 
+// JSONImported : JSON container
+type JSONImported struct {
+	Type           string                    `json:"type"`
+	To             string                    `json:"to"`
+	Input          string                    `json:"input"`
+	Result         string                    `json:"result"`
+	Approximations []symbolset.Approximation `json:"approximations"`
+}
+
+var mapperImportIPA = urlHandler{
+	name:     "import_ipa",
+	url:      "/import_ipa/{to}/{trans}",
+	help:     "Maps a general IPA transcription (e.g., from Wikipedia or Wiktionary) into a symbol set. IPA segments that are not in the symbol set are replaced by the closest symbols, and each approximation is reported. White space separates words. The transcription cannot contain slashes, since it is part of the URL path: use square brackets (or no brackets), or use import_ipa_query for transcriptions like /ˈhɛːst/.",
+	examples: []string{"/import_ipa/sv-se_ws-sampa-DEMO/[ˈtʰɑ̝ːk]"},
+	handler:  importIPAHandler,
+}
+
+var mapperImportIPAQuery = urlHandler{
+	name:     "import_ipa_query",
+	url:      "/import_ipa/{to}",
+	help:     "Same as import_ipa, with the transcription in the query parameter 'trans', so that it can contain slashes.",
+	examples: []string{"/import_ipa/sv-se_ws-sampa-DEMO?trans=/ˈhɛːst ˈhɛːst/"},
+	handler:  importIPAHandler,
+}
+
+func importIPAHandler(w http.ResponseWriter, r *http.Request) {
+	toName := getParam("to", r)
+	trans := trimTrans(getParam("trans", r))
+	if len(strings.TrimSpace(toName)) == 0 {
+		msg := "output symbol set should be specified by variable 'to'"
+		log.Println(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+	if len(trans) == 0 {
+		msg := "input trans should be specified by variable 'trans'"
+		log.Println(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+	mMut.Lock()
+	result0, approximations, err := mMut.service.MapFromGeneralIPA(toName, trans)
+	mMut.Unlock()
+	if err != nil {
+		msg := fmt.Sprintf("couldn't import ipa : %v", err)
+		log.Println(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+	if approximations == nil {
+		approximations = []symbolset.Approximation{}
+	}
+	result := JSONImported{Type: "result", To: toName, Input: trans, Result: result0, Approximations: approximations}
+	j, err := json.Marshal(result)
+	if err != nil {
+		msg := fmt.Sprintf("json marshalling error : %v", err)
+		log.Println(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprint(w, string(j))
+}
+
 // JSONMapper : JSON container
 type JSONMapper struct {
 	From    string
@@ -137,7 +207,7 @@ type JSONMapper struct {
 type JSONMSymbol struct {
 	From string
 	To   string
-	IPA  JSONIPA
+	IPA  symbolset.JSONIPA
 	Desc string
 	Cat  string
 }
@@ -209,7 +279,7 @@ var mapperMaptable = urlHandler{
 				http.Error(w, msg, http.StatusInternalServerError)
 				return
 			}
-			mapper.Symbols = append(mapper.Symbols, JSONMSymbol{From: from.String, To: to.String, IPA: JSONIPA{String: from.IPA.String, Unicode: from.IPA.Unicode}, Desc: from.Desc, Cat: from.Cat.String()})
+			mapper.Symbols = append(mapper.Symbols, JSONMSymbol{From: from.String, To: to.String, IPA: symbolset.JSONIPA{String: from.IPA.String, Unicode: from.IPA.Unicode}, Desc: from.Desc, Cat: from.Cat.String()})
 		}
 
 		j, err := json.Marshal(mapper)
@@ -16,26 +16,6 @@ import (
 	"github.com/stts-se/symbolset"
 )
 
-// JSONSymbolSet : JSON container
-type JSONSymbolSet struct {
-	Name    string
-	Symbols []JSONSymbol
-}
-
-// JSONSymbol : JSON container
-type JSONSymbol struct {
-	Symbol string
-	IPA    JSONIPA
-	Desc   string
-	Cat    string
-}
-
-// JSONIPA : JSON container
-type JSONIPA struct {
-	String  string
-	Unicode string
-}
-
 func (rout *subRouter) addHandler(handler urlHandler) {
 	rout.router.HandleFunc(handler.url, handler.handler)
 	rout.handlers = append(rout.handlers, handler)
@@ -84,13 +64,7 @@ var symbolsetContent = urlHandler{
 			http.Error(w, msg, http.StatusInternalServerError)
 			return
 		}
-		symbolset := JSONSymbolSet{Name: symbolset0.Name}
-		symbolset.Symbols = make([]JSONSymbol, 0)
-		for _, sym := range symbolset0.Symbols {
-			symbolset.Symbols = append(symbolset.Symbols, JSONSymbol{Symbol: sym.String, IPA: JSONIPA{String: sym.IPA.String, Unicode: sym.IPA.Unicode}, Desc: sym.Desc, Cat: sym.Cat.String()})
-		}
-
-		j, err := json.Marshal(symbolset)
+		j, err := json.Marshal(symbolset0.ToJSON())
 		if err != nil {
 			msg := fmt.Sprintf("json marshalling error : %v", err)
 			log.Println(msg)
@@ -112,7 +86,11 @@ func loadSymbolSets(dirName string) error {
 	}
 	mMut.Lock()
 	mMut.service.SymbolSets = symbolSets
+	err = mMut.service.LoadBuiltins()
 	mMut.Unlock()
+	if err != nil {
+		return err
+	}
 
 	mappersDef := filepath.Join(dirName, "mappers.txt")
 	return testMappers(mappersDef)
@@ -0,0 +1,189 @@
+package symbolset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stts-se/symbolset/ipa"
+)
+
+// generation of symbol set skeletons from an IPA inventory
+
+// SkeletonOptions are the options for generating a symbol set from an IPA inventory, see NewSymbolSetSkeleton
+type SkeletonOptions struct {
+	// Scheme is an optional symbol set used to transliterate the IPA symbols (e.g., the built-in X-SAMPA symbol set). If nil, the IPA symbols are used as symbols.
+	Scheme *SymbolSet
+
+	// PhonemeDelimiter is the phoneme delimiter (typically a single space, or an empty string)
+	PhonemeDelimiter string
+
+	// SyllableDelimiter, MorphemeDelimiter and CompoundDelimiter are optional delimiter symbols (an empty string means no delimiter).
+	// The syllable delimiter is mapped to IPA '.', and the others to an empty IPA string.
+	SyllableDelimiter string
+	MorphemeDelimiter string
+	CompoundDelimiter string
+}
+
+// InferCategory returns the symbol category for an IPA symbol, using the IPA character database (see package ipa):
+// symbols with a vowel or a syllabic mark (and no non-syllabic mark) are Syllabic, and other symbols with a consonant are NonSyllabic.
+// Symbols without a segment are Stress (if they contain a stress mark), Tone (tone letters and tone marks), Length (length marks), IntonationBoundary (| and ‖) or Diacritic.
+func InferCategory(ipaSymbol string) SymbolCat {
+	hasVowel, hasSegment := false, false
+	hasStress, hasTone, hasLength, hasBoundary, hasDiacritic := false, false, false, false, false
+	for _, r := range ipaSymbol {
+		switch {
+		case r == nonSyllabicMark:
+			return NonSyllabic
+		case r == syllabicMark || r == syllabicMarkAlt:
+			hasVowel = true
+		}
+		switch ipa.Classify(r) {
+		case ipa.Vowel:
+			hasVowel = true
+			hasSegment = true
+		case ipa.Consonant, ipa.Unknown:
+			hasSegment = true
+		case ipa.Diacritic:
+			if isToneMark(r) {
+				hasTone = true
+			} else {
+				hasDiacritic = true
+			}
+		case ipa.Suprasegmental:
+			switch {
+			case isStressMark(r) && !isToneMark(r):
+				hasStress = true
+			case isStressMark(r):
+				hasTone = true
+			case isLengthMark(r):
+				hasLength = true
+			case r == '|' || r == '‖':
+				hasBoundary = true
+			default:
+				hasSegment = true
+			}
+		}
+	}
+	switch {
+	case hasVowel:
+		return Syllabic
+	case hasSegment || len(ipaSymbol) == 0:
+		return NonSyllabic
+	case hasStress:
+		return Stress
+	case hasTone:
+		return Tone
+	case hasBoundary:
+		return IntonationBoundary
+	case hasLength && !hasDiacritic:
+		return Length
+	}
+	return Diacritic
+}
+
+// isStressMark returns true for suprasegmentals used for stress, accent and tone (as opposed to length marks and boundaries)
+func isStressMark(r rune) bool {
+	c, _ := ipa.Lookup(r)
+	desc := strings.ToLower(c.Desc)
+	return strings.Contains(desc, "stress") || strings.Contains(desc, "tone") || strings.Contains(desc, "step")
+}
+
+// isToneMark returns true for tone marks, tone letters and steps
+func isToneMark(r rune) bool {
+	c, _ := ipa.Lookup(r)
+	desc := strings.ToLower(c.Desc)
+	return strings.Contains(desc, "tone") || strings.Contains(desc, "step")
+}
+
+// isLengthMark returns true for the long, half-long and extra-short marks
+func isLengthMark(r rune) bool {
+	c, _ := ipa.Lookup(r)
+	desc := strings.ToLower(c.Desc)
+	return desc == "long" || desc == "half-long" || desc == "extra-short"
+}
+
+// transliterate returns the symbol for an IPA symbol in the scheme: the symbol with the same IPA, or the symbols for each part of the IPA symbol
+func transliterate(scheme SymbolSet, ipaSymbol string) (string, error) {
+	if sym, err := scheme.GetFromInternalIPA(ipaSymbol); err == nil {
+		return sym.String, nil
+	}
+	splitted, err := scheme.SplitInternalIPATranscription(ipaSymbol)
+	if err != nil {
+		return "", fmt.Errorf("no transliteration for /%s/ in %s : %w", ipaSymbol, scheme.Name, err)
+	}
+	var res []string
+	for _, s := range splitted {
+		sym, err := scheme.GetFromInternalIPA(s)
+		if err != nil {
+			return "", fmt.Errorf("no transliteration for /%s/ in %s : %w", ipaSymbol, scheme.Name, err)
+		}
+		res = append(res, sym.String)
+	}
+	return strings.Join(res, ""), nil
+}
+
+// NewSymbolSetSkeleton creates a symbol set from a list of IPA symbols. Categories are inferred from the IPA (see InferCategory), and descriptions are taken from the IPA character database (see DescribeIPA).
+// The symbols are transliterated using the scheme in the options, if any. The symbol set can be written to file using SymbolSet.WriteSym.
+func NewSymbolSetSkeleton(name string, inventory []string, opts SkeletonOptions) (SymbolSet, error) {
+	var symbols []Symbol
+	seen := make(map[string]string)
+	add := func(symbol string, ipaSymbol string, cat SymbolCat, desc string) error {
+		if prev, exists := seen[symbol]; exists {
+			return fmt.Errorf("symbol /%s/ is used for both /%s/ and /%s/", symbol, prev, ipaSymbol)
+		}
+		seen[symbol] = ipaSymbol
+		unicode := ""
+		if len(ipaSymbol) > 0 {
+			unicode = string2unicode(ipaSymbol)
+		}
+		symbols = append(symbols, Symbol{String: symbol, Cat: cat, Desc: desc, IPA: IPASymbol{String: ipaSymbol, Unicode: unicode}})
+		return nil
+	}
+
+	for _, ipaSymbol := range inventory {
+		ipaSymbol = strings.TrimSpace(ipaSymbol)
+		if len(ipaSymbol) == 0 {
+			continue
+		}
+		symbol := ipaSymbol
+		if opts.Scheme != nil {
+			var err error
+			if symbol, err = transliterate(*opts.Scheme, ipaSymbol); err != nil {
+				return SymbolSet{}, err
+			}
+		}
+		if err := add(symbol, ipaSymbol, InferCategory(ipaSymbol), DescribeIPA(ipaSymbol)); err != nil {
+			return SymbolSet{}, err
+		}
+	}
+
+	for _, d := range []struct {
+		symbol, ipa string
+		cat         SymbolCat
+		desc        string
+	}{
+		{opts.SyllableDelimiter, ".", SyllableDelimiter, "syllable delimiter"},
+		{opts.MorphemeDelimiter, "", MorphemeDelimiter, "morpheme delimiter"},
+		{opts.CompoundDelimiter, "", CompoundDelimiter, "compound delimiter"},
+	} {
+		if len(d.symbol) == 0 {
+			continue
+		}
+		if err := add(d.symbol, d.ipa, d.cat, d.desc); err != nil {
+			return SymbolSet{}, err
+		}
+	}
+	if err := add(opts.PhonemeDelimiter, "", PhonemeDelimiter, "phoneme delimiter"); err != nil {
+		return SymbolSet{}, err
+	}
+
+	ssType := IPA
+	if opts.Scheme != nil {
+		ssType = opts.Scheme.Type
+	}
+	ss, err := NewSymbolSetWithOptions(name, symbols, SymbolSetOptions{Metadata: map[string]string{"TYPE": ssType.String()}})
+	if err != nil {
+		return SymbolSet{}, fmt.Errorf("couldn't create symbol set %s : %w", name, err)
+	}
+	return ss, nil
+}
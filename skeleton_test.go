@@ -0,0 +1,104 @@
+package symbolset
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_InferCategory(t *testing.T) {
+	for input, exp := range map[string]SymbolCat{
+		"a":   Syllabic,
+		"aː":  Syllabic,
+		"ɛ̃":  Syllabic,
+		"l̩":  Syllabic,
+		"i̯":  NonSyllabic,
+		"a⁀ɪ": Syllabic,
+		"t⁀s": NonSyllabic,
+		"pʰ":  NonSyllabic,
+		"ŋ":   NonSyllabic,
+		"ˈ":   Stress,
+		"ˌ":   Stress,
+		"ˈ̀":  Stress,
+		"˥":   Tone,
+		"˧˥":  Tone,
+		"ꜜ":   Tone,
+		"ː":   Length,
+		"ˑ":   Length,
+		"ʰ":   Diacritic,
+		"̃":   Diacritic,
+		"|":   IntonationBoundary,
+		"‖":   IntonationBoundary,
+	} {
+		if got := InferCategory(input); got != exp {
+			t.Errorf("InferCategory(%s) expected %s, got %s", input, exp, got)
+		}
+	}
+}
+
+func Test_NewSymbolSetSkeleton(t *testing.T) {
+	inventory := []string{"a", "ɑː", "ɛ", "p", "pʰ", "t⁀ʃ", "ŋ", "ˈ", "ˌ"}
+	ss, err := NewSymbolSetSkeleton("xx-xx_ipa", inventory, SkeletonOptions{PhonemeDelimiter: " ", SyllableDelimiter: "."})
+	if err != nil {
+		t.Fatalf("NewSymbolSetSkeleton() didn't expect error here : %v", err)
+	}
+	if ss.Type != IPA {
+		t.Errorf("expected type %s, got %s", IPA, ss.Type)
+	}
+	var buf bytes.Buffer
+	if err := ss.WriteSym(&buf); err != nil {
+		t.Fatalf("WriteSym() didn't expect error here : %v", err)
+	}
+	exp := `DESCRIPTION	SYMBOL	IPA	IPA UNICODE	CATEGORY
+TYPE	IPA
+open front unrounded vowel	a	a	U+0061	Syllabic
+open back unrounded vowel (long)	ɑː	ɑː	U+0251U+02D0	Syllabic
+open-mid front unrounded vowel	ɛ	ɛ	U+025B	Syllabic
+voiceless bilabial plosive	p	p	U+0070	NonSyllabic
+voiceless bilabial plosive, aspirated	pʰ	pʰ	U+0070U+02B0	NonSyllabic
+voiceless alveolar plosive, tie bar, voiceless postalveolar fricative	t⁀ʃ	t⁀ʃ	U+0074U+2040U+0283	NonSyllabic
+velar nasal	ŋ	ŋ	U+014B	NonSyllabic
+primary stress	ˈ	ˈ	U+02C8	Stress
+secondary stress	ˌ	ˌ	U+02CC	Stress
+syllable delimiter	.	.	U+002E	SyllableDelimiter
+phoneme delimiter	 			PhonemeDelimiter
+`
+	if got := buf.String(); got != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, got)
+	}
+	if _, err := ReadSymbolSet("xx-xx_ipa", strings.NewReader(buf.String())); err != nil {
+		t.Errorf("ReadSymbolSet() didn't expect error here : %v", err)
+	}
+}
+
+func Test_NewSymbolSetSkeleton_WithScheme(t *testing.T) {
+	scheme, err := LoadSymbolSet("builtin/x-sampa.sym")
+	if err != nil {
+		t.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	inventory := []string{"a", "ɑː", "pʰ", "t⁀ʃ", "ŋ", "ɛ̃", "ˈ"}
+	ss, err := NewSymbolSetSkeleton("xx-xx_sampa", inventory, SkeletonOptions{Scheme: &scheme, SyllableDelimiter: ".", CompoundDelimiter: "-"})
+	if err != nil {
+		t.Fatalf("NewSymbolSetSkeleton() didn't expect error here : %v", err)
+	}
+	var got []string
+	for _, sym := range ss.Symbols {
+		got = append(got, sym.String+"/"+sym.IPA.String+"/"+sym.Cat.String())
+	}
+	exp := "a/a/Syllabic A:/ɑː/Syllabic p_h/pʰ/NonSyllabic tS/t⁀ʃ/NonSyllabic N/ŋ/NonSyllabic E~/ɛ̃/Syllabic \"/ˈ/Stress ././SyllableDelimiter -//CompoundDelimiter //PhonemeDelimiter"
+	if strings.Join(got, " ") != exp {
+		t.Errorf("expected %s, got %s", exp, strings.Join(got, " "))
+	}
+	if ss.Type != SAMPA {
+		t.Errorf("expected type %s, got %s", SAMPA, ss.Type)
+	}
+
+	// no transliteration
+	if _, err := NewSymbolSetSkeleton("xx-xx_sampa", []string{"a", "˥"}, SkeletonOptions{Scheme: &scheme}); err == nil {
+		t.Errorf("NewSymbolSetSkeleton() expected error for missing transliteration")
+	}
+	// duplicate symbol
+	if _, err := NewSymbolSetSkeleton("xx-xx_sampa", []string{"ɡ", "g"}, SkeletonOptions{Scheme: &scheme}); err == nil {
+		t.Errorf("NewSymbolSetSkeleton() expected error for duplicate symbol")
+	}
+}
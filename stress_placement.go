@@ -0,0 +1,279 @@
+package symbolset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// declarative stress placement
+
+// StressPlacement is used to declare where stress, accent and tone symbols are placed in the transcriptions of a symbol set.
+// It can be declared in the .sym file using a STRESS_PLACEMENT line, e.g.:
+//
+//	STRESS_PLACEMENT	BeforeSyllable
+type StressPlacement int
+
+const (
+	// StressUndefined is used for symbol sets without a declared stress placement. Stress will be filtered according to the symbol set's Type.
+	StressUndefined StressPlacement = iota
+
+	// StressBeforeSyllable is used when stress symbols precede the syllable (e.g., Wikispeech SAMPA: ' p l { . t @)
+	StressBeforeSyllable
+
+	// StressBeforeNucleus is used when stress symbols precede the syllabic phoneme (e.g., p l ' { . t @)
+	StressBeforeNucleus
+
+	// StressAfterNucleus is used when stress symbols are attached to the end of the syllabic phoneme, like the CMU stress digits (e.g., P L AE1 $ T AH0)
+	StressAfterNucleus
+
+	// StressToneLetter is used when stress or tone symbols follow the syllable, like tone letters (e.g., m a ˥˩)
+	StressToneLetter
+)
+
+var stressPlacementNames = []string{"Undefined", "BeforeSyllable", "BeforeNucleus", "AfterNucleus", "ToneLetter"}
+
+func (sp StressPlacement) String() string {
+	if sp < 0 || int(sp) >= len(stressPlacementNames) {
+		return fmt.Sprintf("StressPlacement(%d)", sp)
+	}
+	return stressPlacementNames[sp]
+}
+
+// StressPlacementFromString returns the stress placement with the given name (as returned by StressPlacement.String)
+func StressPlacementFromString(s string) (StressPlacement, error) {
+	for i, name := range stressPlacementNames {
+		if s == name {
+			return StressPlacement(i), nil
+		}
+	}
+	return StressUndefined, fmt.Errorf("invalid stress placement '%s' (expected one of %s)", s, strings.Join(stressPlacementNames[1:], ", "))
+}
+
+var stressPlacementDirective = "STRESS_PLACEMENT"
+
+func isStressPlacementLine(l string) bool {
+	return strings.HasPrefix(l, stressPlacementDirective+"\t")
+}
+
+func parseStressPlacementLine(l string) (StressPlacement, error) {
+	fs := strings.Split(l, "\t")
+	if len(fs) != 2 {
+		return StressUndefined, fmt.Errorf("%s line must have 2 fields, found %s", stressPlacementDirective, l)
+	}
+	return StressPlacementFromString(strings.TrimSpace(fs[1]))
+}
+
+// stressToken is a transcription symbol used for stress placement. Input symbols unknown to the symbol set are treated as non-syllabic phonemes.
+type stressToken struct {
+	s    string
+	cat  SymbolCat
+	join bool // attached to the preceding token, without phoneme delimiter
+}
+
+// stressGroup is a sequence of stress symbols, placed before phoneme number 'pos' in the chunk
+type stressGroup struct {
+	tokens []stressToken
+	pos    int
+}
+
+// stressTokenize splits the transcription for stress placement. Stress symbols attached to the end of a phoneme (e.g., CMU: AE1) are split into separate tokens.
+// If the transcription cannot be split, ok is false.
+func (ss SymbolSet) stressTokenize(trans string) ([]stressToken, bool) {
+	splitted, err := ss.SplitTranscription(trans)
+	if err != nil {
+		return nil, false
+	}
+	var res []stressToken
+	for _, s := range splitted {
+		if sym, err := ss.Get(s); err == nil {
+			res = append(res, stressToken{s: s, cat: sym.Cat})
+			continue
+		}
+		var stress []stressToken
+		for len(s) > 0 {
+			found := false
+			for _, st := range ss.stressSymbols {
+				if len(st.String) > 0 && len(st.String) < len(s) && strings.HasSuffix(s, st.String) {
+					stress = append([]stressToken{{s: st.String, cat: Stress}}, stress...)
+					s = strings.TrimSuffix(s, st.String)
+					found = true
+					break
+				}
+			}
+			if !found || ss.ValidSymbol(s) {
+				break
+			}
+		}
+		cat := NonSyllabic
+		if sym, err := ss.Get(s); err == nil {
+			cat = sym.Cat
+		}
+		res = append(res, stressToken{s: s, cat: cat})
+		res = append(res, stress...)
+	}
+	return res, true
+}
+
+func (ss SymbolSet) stressJoin(tokens []stressToken) string {
+	var res strings.Builder
+	for i, t := range tokens {
+		if i > 0 && !t.join {
+			res.WriteString(ss.PhonemeDelimiter.String)
+		}
+		res.WriteString(t.s)
+	}
+	return res.String()
+}
+
+// placeStress moves the stress symbols in the input tokens, chunk by chunk (a chunk is delimited by syllable, morpheme, compound and word delimiters, pauses and intonation boundaries).
+// Each stress group belongs to a syllabic phoneme in the chunk, searched forward (the next syllabic) or backward (the preceding syllabic) depending on the input placement.
+// The stress group is then placed according to the output placement. Stress placed after the syllabic phoneme is also placed after any length marks and diacritics attached to it.
+// Tone symbols are not moved.
+// If a chunk has more than one syllabic phoneme, the syllable boundaries are unknown, and stress placed before/after the syllable will instead be placed before/after the syllabic phoneme.
+func placeStress(tokens []stressToken, from StressPlacement, to StressPlacement) []stressToken {
+	var res []stressToken
+	var chunk []stressToken
+	for _, t := range tokens {
+		switch t.cat {
+		case SyllableDelimiter, MorphemeDelimiter, CompoundDelimiter, WordDelimiter, Pause, IntonationBoundary:
+			res = append(res, placeStressInChunk(chunk, from, to)...)
+			res = append(res, t)
+			chunk = nil
+		default:
+			chunk = append(chunk, t)
+		}
+	}
+	return append(res, placeStressInChunk(chunk, from, to)...)
+}
+
+func placeStressInChunk(chunk []stressToken, from StressPlacement, to StressPlacement) []stressToken {
+	var phonemes []stressToken
+	var groups []stressGroup
+	for _, t := range chunk {
+		if t.cat == Stress {
+			t.join = false
+			if n := len(groups); n > 0 && groups[n-1].pos == len(phonemes) {
+				groups[n-1].tokens = append(groups[n-1].tokens, t)
+			} else {
+				groups = append(groups, stressGroup{tokens: []stressToken{t}, pos: len(phonemes)})
+			}
+		} else {
+			phonemes = append(phonemes, t)
+		}
+	}
+	if len(groups) == 0 {
+		return chunk
+	}
+
+	var syllabic []int
+	for i, p := range phonemes {
+		if p.cat == Syllabic {
+			syllabic = append(syllabic, i)
+		}
+	}
+	forward := func(pos int) int {
+		for _, i := range syllabic {
+			if i >= pos {
+				return i
+			}
+		}
+		return -1
+	}
+	backward := func(pos int) int {
+		for j := len(syllabic) - 1; j >= 0; j-- {
+			if syllabic[j] < pos {
+				return syllabic[j]
+			}
+		}
+		return -1
+	}
+	// afterNucleus returns the position following the syllabic phoneme, and any length marks and diacritics attached to it
+	afterNucleus := func(nucleus int) int {
+		pos := nucleus + 1
+		for pos < len(phonemes) && attachesToPhoneme(phonemes[pos].cat) {
+			pos++
+		}
+		return pos
+	}
+
+	// before[i] holds the stress groups to insert before phoneme i (i == len(phonemes) for the end of the chunk)
+	before := make(map[int][]stressToken)
+	for _, g := range groups {
+		var nucleus int
+		if from == StressAfterNucleus || from == StressToneLetter {
+			if nucleus = backward(g.pos); nucleus < 0 {
+				nucleus = forward(g.pos)
+			}
+		} else {
+			if nucleus = forward(g.pos); nucleus < 0 {
+				nucleus = backward(g.pos)
+			}
+		}
+		pos := g.pos
+		tokens := g.tokens
+		if nucleus >= 0 {
+			switch to {
+			case StressBeforeSyllable:
+				pos = nucleus
+				if len(syllabic) == 1 {
+					pos = 0
+				}
+			case StressBeforeNucleus:
+				pos = nucleus
+			case StressAfterNucleus:
+				pos = afterNucleus(nucleus)
+				for i := range tokens {
+					tokens[i].join = true
+				}
+			case StressToneLetter:
+				pos = afterNucleus(nucleus)
+				if len(syllabic) == 1 {
+					pos = len(phonemes)
+				}
+			}
+		}
+		before[pos] = append(before[pos], tokens...)
+	}
+
+	var res []stressToken
+	for i := 0; i <= len(phonemes); i++ {
+		// stress attached to the end of the preceding phoneme goes first
+		for _, t := range before[i] {
+			if t.join {
+				res = append(res, t)
+			}
+		}
+		for _, t := range before[i] {
+			if !t.join {
+				res = append(res, t)
+			}
+		}
+		if i < len(phonemes) {
+			res = append(res, phonemes[i])
+		}
+	}
+	return res
+}
+
+// filterBeforeMappingFromStressPlacement moves stress symbols in a transcription using the declared stress placement, so that the stress symbols precede the syllabic phoneme.
+func filterBeforeMappingFromStressPlacement(ss SymbolSet, trans string) (string, error) {
+	if ss.StressPlacement == StressBeforeNucleus {
+		return trans, nil
+	}
+	tokens, ok := ss.stressTokenize(trans)
+	if !ok {
+		// unknown symbols will be reported later on
+		return trans, nil
+	}
+	return ss.stressJoin(placeStress(tokens, ss.StressPlacement, StressBeforeNucleus)), nil
+}
+
+// filterAfterMappingToStressPlacement moves stress symbols in a transcription according to the declared stress placement.
+// The stress symbols in the input transcription should precede the syllable or the syllabic phoneme.
+func filterAfterMappingToStressPlacement(ss SymbolSet, trans string) (string, error) {
+	tokens, ok := ss.stressTokenize(trans)
+	if !ok {
+		return trans, nil
+	}
+	return ss.stressJoin(placeStress(tokens, StressBeforeSyllable, ss.StressPlacement)), nil
+}
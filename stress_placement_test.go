@@ -0,0 +1,183 @@
+package symbolset
+
+import (
+	"testing"
+)
+
+func stressPlacementTestSet(t *testing.T, name string, placement StressPlacement, stress string, extra ...Symbol) SymbolSet {
+	symbols := []Symbol{
+		{String: "p", Cat: NonSyllabic, IPA: IPASymbol{String: "p", Unicode: "U+0070"}},
+		{String: "l", Cat: NonSyllabic, IPA: IPASymbol{String: "l", Unicode: "U+006C"}},
+		{String: "t", Cat: NonSyllabic, IPA: IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: "m", Cat: NonSyllabic, IPA: IPASymbol{String: "m", Unicode: "U+006D"}},
+		{String: "a", Cat: Syllabic, IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "e", Cat: Syllabic, IPA: IPASymbol{String: "e", Unicode: "U+0065"}},
+		{String: ".", Cat: SyllableDelimiter, IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
+		{String: " ", Cat: PhonemeDelimiter, IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: stress, Cat: Stress, IPA: IPASymbol{String: "ˈ", Unicode: "U+02C8"}},
+	}
+	ss, err := NewSymbolSetWithOptions(name, append(symbols, extra...), SymbolSetOptions{StressPlacement: placement})
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	return ss
+}
+
+func Test_StressPlacement_ConvertToInternalIPA(t *testing.T) {
+	var tests = []struct {
+		placement StressPlacement
+		stress    string
+		input     string
+		expect    string
+	}{
+		{StressBeforeSyllable, "'", "' p l a . t e", "ˈpla.te"},
+		{StressBeforeSyllable, "'", "p a . ' l a . t e", "pa.ˈla.te"},
+		{StressBeforeNucleus, "'", "p l ' a . t e", "ˈpla.te"},
+		{StressBeforeNucleus, "'", "p a . l ' a . t e", "pa.ˈla.te"},
+		{StressAfterNucleus, "1", "p l a1 . t e", "ˈpla.te"},
+		{StressAfterNucleus, "1", "p a . l a1 . t e", "pa.ˈla.te"},
+		{StressToneLetter, "1", "p l a 1 . t e", "ˈpla.te"},
+		{StressToneLetter, "1", "p a . l a t 1 . t e", "pa.ˈlat.te"},
+	}
+	for _, test := range tests {
+		ss := stressPlacementTestSet(t, "test", test.placement, test.stress)
+		result, err := ss.ConvertToInternalIPA(test.input)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("%v /%s/: "+fsExp, test.placement, test.input, test.expect, result)
+		}
+	}
+}
+
+func Test_StressPlacement_ConvertFromInternalIPA(t *testing.T) {
+	var tests = []struct {
+		placement StressPlacement
+		stress    string
+		input     string
+		expect    string
+	}{
+		{StressBeforeSyllable, "'", "ˈpla.te", "' p l a . t e"},
+		{StressBeforeSyllable, "'", "pa.ˈla.te", "p a . ' l a . t e"},
+		{StressBeforeNucleus, "'", "ˈpla.te", "p l ' a . t e"},
+		{StressBeforeNucleus, "'", "pa.ˈla.te", "p a . l ' a . t e"},
+		{StressAfterNucleus, "1", "ˈpla.te", "p l a1 . t e"},
+		{StressAfterNucleus, "1", "pa.ˈla.te", "p a . l a1 . t e"},
+		{StressToneLetter, "1", "ˈpla.te", "p l a 1 . t e"},
+		{StressToneLetter, "1", "pa.ˈlat.te", "p a . l a t 1 . t e"},
+
+		// no syllable delimiters: stress is placed relative to the syllabic phoneme
+		{StressBeforeSyllable, "'", "plaˈte", "p l a t ' e"},
+		{StressToneLetter, "1", "plaˈte", "p l a t e 1"},
+		{StressToneLetter, "1", "ˈplate", "p l a 1 t e"},
+	}
+	for _, test := range tests {
+		ss := stressPlacementTestSet(t, "test", test.placement, test.stress)
+		result, err := ss.ConvertFromInternalIPA(test.input)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("%v /%s/: "+fsExp, test.placement, test.input, test.expect, result)
+		}
+	}
+}
+
+func Test_StressPlacement_ToneLetters(t *testing.T) {
+	tones := []Symbol{
+		{String: "˥", Cat: Stress, IPA: IPASymbol{String: "˥", Unicode: "U+02E5"}},
+		{String: "˩", Cat: Stress, IPA: IPASymbol{String: "˩", Unicode: "U+02E9"}},
+	}
+	ss := stressPlacementTestSet(t, "test", StressToneLetter, "'", tones...)
+	input := "m a ˥ ˩ . m a ˩"
+	ipa, err := ss.ConvertToInternalIPA(input)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	result, err := ss.ConvertFromInternalIPA(ipa)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if result != input {
+		t.Errorf(fsExp, input, result)
+	}
+}
+
+func Test_StressPlacementFromString(t *testing.T) {
+	for _, sp := range []StressPlacement{StressBeforeSyllable, StressBeforeNucleus, StressAfterNucleus, StressToneLetter} {
+		result, err := StressPlacementFromString(sp.String())
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+		}
+		if result != sp {
+			t.Errorf(fsExp, sp, result)
+		}
+	}
+	if _, err := StressPlacementFromString("BeforeWord"); err == nil {
+		t.Errorf("expected error for invalid stress placement")
+	}
+}
+
+func Test_LoadSymbolSet_StressPlacement(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if ss.StressPlacement != StressAfterNucleus {
+		t.Errorf(fsExp, StressAfterNucleus, ss.StressPlacement)
+	}
+
+	ss, err = LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if ss.StressPlacement != StressUndefined {
+		t.Errorf(fsExp, StressUndefined, ss.StressPlacement)
+	}
+}
+
+func Test_StressPlacement_LengthAndDiacritics(t *testing.T) {
+	extra := []Symbol{
+		{String: ":", Cat: Length, IPA: IPASymbol{String: "ː", Unicode: "U+02D0"}},
+		{String: "_h", Cat: Diacritic, IPA: IPASymbol{String: "ʰ", Unicode: "U+02B0"}},
+	}
+	// length marks and diacritics belong to the preceding phoneme
+	var tests = []struct {
+		placement StressPlacement
+		expect    string
+	}{
+		{StressBeforeSyllable, "p _h a . 1 p l a : . t e"},
+		{StressBeforeNucleus, "p _h a . p l 1 a : . t e"},
+		{StressAfterNucleus, "p _h a . p l a :1 . t e"},
+		{StressToneLetter, "p _h a . p l a : 1 . t e"},
+	}
+	for _, test := range tests {
+		ss := stressPlacementTestSet(t, "test", test.placement, "1", extra...)
+		result, err := ss.ConvertFromInternalIPA("pʰa.ˈplaː.te")
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf("%v: "+fsExp, test.placement, test.expect, result)
+		}
+		ipa, err := ss.ConvertToInternalIPA(result)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if ipa != "pʰa.ˈplaː.te" {
+			t.Errorf("%v: "+fsExp, test.placement, "pʰa.ˈplaː.te", ipa)
+		}
+		if diags := ss.CheckWellFormedness(result); len(diags) > 0 {
+			t.Errorf("%v /%s/: expected no diagnostics, found %v", test.placement, result, diags)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package symbolset
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// automatic syllabification of transcriptions without syllable delimiters
+
+// Sonority levels used by the syllabifier. Higher values are more sonorous.
+const (
+	SonorityObstruent = iota
+	SonorityFricative
+	SonorityNasal
+	SonorityLiquid
+	SonorityGlide
+	SonorityVowel
+)
+
+var defaultSonority = map[rune]int{}
+
+func init() {
+	for _, r := range "mɱnɳɲŋɴ" {
+		defaultSonority[r] = SonorityNasal
+	}
+	for _, r := range "ɸβfvθðszʃʒʂʐçʝxɣχʁħʕhɦɕʑɧɬɮ" {
+		defaultSonority[r] = SonorityFricative
+	}
+	for _, r := range "lɭʎʟɫrɾɽʀɹɻɺ" {
+		defaultSonority[r] = SonorityLiquid
+	}
+	for _, r := range "jwɥɰʋʍ" {
+		defaultSonority[r] = SonorityGlide
+	}
+}
+
+// SyllabificationRules holds the language specific constraints used by the Syllabifier
+type SyllabificationRules struct {
+	// Onsets lists the legal onset clusters (sequences of two or more symbols). Single consonant onsets are always legal. If Onsets is empty, any onset with rising sonority is legal.
+	Onsets [][]string
+
+	// Codas lists the legal coda clusters (sequences of two or more symbols). Single consonant codas are always legal. If Codas is empty, any coda with falling sonority is legal.
+	Codas [][]string
+
+	// Sonority can be used to override the sonority level for individual symbols. By default, the sonority is derived from the symbol's IPA.
+	Sonority map[string]int
+}
+
+// Syllabifier inserts syllable delimiters into transcriptions, using the sonority and maximal onset principles. To create a new Syllabifier, use NewSyllabifier.
+type Syllabifier struct {
+	SymbolSet SymbolSet
+	Rules     SyllabificationRules
+
+	syllableDelimiter Symbol
+	sonority          map[string]int
+}
+
+// NewSyllabifier creates a syllabifier for the symbol set. The symbol set needs to have a non-empty syllable delimiter.
+func NewSyllabifier(ss SymbolSet, rules SyllabificationRules) (Syllabifier, error) {
+	if !ss.isInit {
+		return Syllabifier{}, fmt.Errorf("symbolSet %s has not been initialized properly", ss.Name)
+	}
+	var syllDelim Symbol
+	var foundDelim bool
+	for _, s := range filterSymbolsByCat(ss.Symbols, []SymbolCat{SyllableDelimiter}) {
+		if len(s.String) > 0 {
+			syllDelim = s
+			foundDelim = true
+			break
+		}
+	}
+	if !foundDelim {
+		return Syllabifier{}, fmt.Errorf("no syllable delimiter defined in symbol set %s", ss.Name)
+	}
+	sonority := make(map[string]int)
+	for _, s := range ss.nonSyllabic {
+		sonority[s.String] = ipaSonority(s.IPA.String)
+	}
+	for _, s := range ss.syllabic {
+		sonority[s.String] = SonorityVowel
+	}
+	for sym, son := range rules.Sonority {
+		sonority[sym] = son
+	}
+	return Syllabifier{
+		SymbolSet:         ss,
+		Rules:             rules,
+		syllableDelimiter: syllDelim,
+		sonority:          sonority,
+	}, nil
+}
+
+// ipaSonority returns the sonority level of an IPA consonant, based on its first character. Unknown characters are treated as obstruents.
+func ipaSonority(ipa string) int {
+	for _, r := range ipa {
+		if son, ok := defaultSonority[r]; ok {
+			return son
+		}
+		return SonorityObstruent
+	}
+	return SonorityObstruent
+}
+
+// Syllabify is a convenience method for syllabifying a transcription with the specified rules
+func (ss SymbolSet) Syllabify(trans string, rules SyllabificationRules) (string, error) {
+	syllabifier, err := NewSyllabifier(ss, rules)
+	if err != nil {
+		return "", err
+	}
+	return syllabifier.Syllabify(trans)
+}
+
+// syllUnit is a phoneme, along with any stress symbols preceding it, and any tone symbols, length marks and diacritics following it
+type syllUnit struct {
+	prefix  []string
+	phoneme string
+	suffix  []string
+	cat     SymbolCat
+}
+
+// Syllabify inserts syllable delimiters into the input transcription. Existing syllable, morpheme, compound and word delimiters, pauses and intonation boundaries are kept, and the material between them is syllabified separately.
+// Tone symbols, length marks and diacritics stay with the preceding phoneme.
+func (s Syllabifier) Syllabify(trans string) (string, error) {
+	ss := s.SymbolSet
+	res, err := filterBeforeMapping(ss, trans)
+	if err != nil {
+		return "", err
+	}
+	splitted, err := ss.SplitTranscription(res)
+	if err != nil {
+		return "", err
+	}
+
+	var out []string
+	var chunk []syllUnit
+	var pendingStress []string
+	flush := func() {
+		out = append(out, s.syllabifyChunk(chunk)...)
+		out = append(out, pendingStress...)
+		chunk = []syllUnit{}
+		pendingStress = []string{}
+	}
+	var unknown []string
+	for _, phn := range splitted {
+		symbol, err := ss.Get(phn)
+		if err != nil {
+			if !slices.Contains(unknown, phn) {
+				unknown = append(unknown, phn)
+			}
+			continue
+		}
+		switch symbol.Cat {
+		case Stress:
+			pendingStress = append(pendingStress, phn)
+		case Syllabic, NonSyllabic:
+			chunk = append(chunk, syllUnit{prefix: pendingStress, phoneme: phn, cat: symbol.Cat})
+			pendingStress = []string{}
+		case Tone, Length, Diacritic:
+			if len(chunk) > 0 && len(pendingStress) == 0 {
+				chunk[len(chunk)-1].suffix = append(chunk[len(chunk)-1].suffix, phn)
+				continue
+			}
+			flush()
+			out = append(out, phn)
+		default:
+			flush()
+			out = append(out, phn)
+		}
+	}
+	if len(unknown) > 0 {
+		return "", UnknownInputSymbol(unknown)
+	}
+	flush()
+
+	res = strings.Join(out, ss.PhonemeDelimiter.String)
+	return filterAfterMapping(ss, res)
+}
+
+// syllabifyChunk inserts syllable delimiters between the nuclei of a sequence of phonemes
+func (s Syllabifier) syllabifyChunk(units []syllUnit) []string {
+	var nuclei []int
+	for i, u := range units {
+		if u.cat == Syllabic {
+			nuclei = append(nuclei, i)
+		}
+	}
+	boundaries := make(map[int]bool)
+	for i := 1; i < len(nuclei); i++ {
+		from := nuclei[i-1] + 1
+		cluster := []string{}
+		for _, u := range units[from:nuclei[i]] {
+			cluster = append(cluster, u.phoneme)
+		}
+		boundaries[from+s.splitCluster(cluster)] = true
+	}
+
+	var res []string
+	for i, u := range units {
+		if boundaries[i] {
+			res = append(res, s.syllableDelimiter.String)
+		}
+		res = append(res, u.prefix...)
+		res = append(res, u.phoneme)
+		res = append(res, u.suffix...)
+	}
+	return res
+}
+
+// splitCluster returns the index in the consonant cluster where the next syllable starts, preferring the longest legal onset
+func (s Syllabifier) splitCluster(cluster []string) int {
+	for i := 0; i <= len(cluster); i++ {
+		if s.legalOnset(cluster[i:]) && s.legalCoda(cluster[:i]) {
+			return i
+		}
+	}
+	for i := 0; i <= len(cluster); i++ {
+		if s.legalOnset(cluster[i:]) {
+			return i
+		}
+	}
+	return len(cluster)
+}
+
+func (s Syllabifier) legalOnset(onset []string) bool {
+	if len(onset) < 2 {
+		return true
+	}
+	if len(s.Rules.Onsets) > 0 {
+		return containsSequence(s.Rules.Onsets, onset)
+	}
+	for i := 1; i < len(onset); i++ {
+		if s.sonority[onset[i-1]] >= s.sonority[onset[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Syllabifier) legalCoda(coda []string) bool {
+	if len(coda) < 2 {
+		return true
+	}
+	if len(s.Rules.Codas) > 0 {
+		return containsSequence(s.Rules.Codas, coda)
+	}
+	for i := 1; i < len(coda); i++ {
+		if s.sonority[coda[i-1]] <= s.sonority[coda[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsSequence(seqs [][]string, seq []string) bool {
+	for _, s := range seqs {
+		if slices.Equal(s, seq) {
+			return true
+		}
+	}
+	return false
+}
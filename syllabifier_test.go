@@ -0,0 +1,98 @@
+package symbolset
+
+import "testing"
+
+func testSyllabify(t *testing.T, s Syllabifier, input string, expect string) {
+	result, err := s.Syllabify(input)
+	if err != nil {
+		t.Errorf("Syllabify() didn't expect error here; input=%s, expect=%s : %v", input, expect, err)
+		return
+	} else if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+}
+
+func Test_Syllabify_WS_SV(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	s, err := NewSyllabifier(ss, SyllabificationRules{})
+	if err != nil {
+		t.Errorf("NewSyllabifier() didn't expect error here : %v", err)
+		return
+	}
+	testSyllabify(t, s, "\"\" b r A: k a", "\"\" b r A: . k a")
+	testSyllabify(t, s, "\" b O t", "\" b O t")
+	testSyllabify(t, s, "\"\" a s t r a", "\"\" a s . t r a")
+	testSyllabify(t, s, "\"\" f O r n a", "\"\" f O r . n a")
+	testSyllabify(t, s, "\"\" b r A: . k a", "\"\" b r A: . k a")
+	testSyllabify(t, s, "\" a p a % p a", "\" a . p a . % p a")
+	testSyllabify(t, s, "a U", "a . U")
+}
+
+func Test_Syllabify_WithOnsets(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	rules := SyllabificationRules{
+		Onsets: [][]string{{"s", "t", "r"}, {"t", "r"}},
+	}
+	s, err := NewSyllabifier(ss, rules)
+	if err != nil {
+		t.Errorf("NewSyllabifier() didn't expect error here : %v", err)
+		return
+	}
+	testSyllabify(t, s, "\"\" a s t r a", "\"\" a . s t r a")
+	testSyllabify(t, s, "\"\" a p l a", "\"\" a p . l a")
+}
+
+func Test_Syllabify_CMU(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	result, err := ss.Syllabify("P L AE1 T AX P UH2 S", SyllabificationRules{})
+	if err != nil {
+		t.Errorf("Syllabify() didn't expect error here : %v", err)
+		return
+	}
+	expect := "P L AE1 $ T AX $ P UH2 S"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+}
+
+func Test_Syllabify_FailWithoutSyllableDelimiter(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/en-us_cmu-nosylldelim.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	_, err = NewSyllabifier(ss, SyllabificationRules{})
+	if err == nil {
+		t.Errorf("NewSyllabifier() expected error here")
+	}
+}
+
+func Test_Syllabify_ToneAndPause(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/cmn_x-sampa-tone.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	// tone, length and diacritics stay with the preceding phoneme, and pauses are kept as boundaries
+	result, err := ss.Syllabify("m a _1 t a : _4 sil p _h a n i", SyllabificationRules{})
+	if err != nil {
+		t.Errorf("Syllabify() didn't expect error here : %v", err)
+		return
+	}
+	expect := "m a _1 . t a : _4 sil p _h a . n i"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+}
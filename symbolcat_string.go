@@ -4,9 +4,9 @@ package symbolset
 
 import "fmt"
 
-const _SymbolCat_name = "SyllabicNonSyllabicStressPhonemeDelimiterSyllableDelimiterMorphemeDelimiterCompoundDelimiterWordDelimiter"
+const _SymbolCat_name = "SyllabicNonSyllabicStressPhonemeDelimiterSyllableDelimiterMorphemeDelimiterCompoundDelimiterWordDelimiterToneLengthDiacriticPauseIntonationBoundary"
 
-var _SymbolCat_index = [...]uint8{0, 8, 19, 25, 41, 58, 75, 92, 105}
+var _SymbolCat_index = [...]uint8{0, 8, 19, 25, 41, 58, 75, 92, 105, 109, 115, 124, 129, 147}
 
 func (i SymbolCat) String() string {
 	if i < 0 || i >= SymbolCat(len(_SymbolCat_index)-1) {
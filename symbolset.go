@@ -7,7 +7,7 @@ import (
 	"strings"
 )
 
-// Type is used for accent placement, etc.
+// Type is used for accent placement, etc. It can be declared in the .sym file using the TYPE directive. If not declared, the type is derived from the symbol set name.
 type Type int
 
 const (
@@ -56,8 +56,34 @@ const (
 
 	// WordDelimiter is used for word delimiters
 	WordDelimiter
+
+	// Tone is used for tone symbols (tone letters, tone numbers, downstep, etc) that are not stress or accent symbols.
+	// Tone symbols are not moved by the stress filters, and they are not syllable nuclei.
+	Tone
+
+	// Length is used for length marks written as separate symbols (e.g., IPA ː). Length symbols belong to the preceding phoneme.
+	Length
+
+	// Diacritic is used for diacritics written as separate symbols (e.g., IPA ʰ). Diacritic symbols belong to the preceding phoneme.
+	Diacritic
+
+	// Pause is used for pause symbols (e.g., silence markers). Pauses are prosodic boundaries: they separate syllables and words.
+	Pause
+
+	// IntonationBoundary is used for intonation group boundaries (e.g., IPA | and ‖). Intonation boundaries separate syllables and words, like pauses.
+	IntonationBoundary
 )
 
+// attachesToPhoneme returns true for symbol categories that belong to the preceding phoneme (length marks and diacritics)
+func attachesToPhoneme(cat SymbolCat) bool {
+	return cat == Length || cat == Diacritic
+}
+
+// isProsodicBoundary returns true for pauses and intonation boundaries
+func isProsodicBoundary(cat SymbolCat) bool {
+	return cat == Pause || cat == IntonationBoundary
+}
+
 // IPASymbol ipa symbol string with Unicode representation
 type IPASymbol struct {
 	String  string
@@ -66,19 +92,38 @@ type IPASymbol struct {
 
 // Symbol represent a phoneme, stress or delimiter symbol used in transcriptions, including the IPA symbol with unicode
 type Symbol struct {
-	String string
-	Cat    SymbolCat
-	Desc   string
-	IPA    IPASymbol
+	String   string
+	Cat      SymbolCat
+	Desc     string
+	IPA      IPASymbol
+	Features Features
 }
 
 // SymbolSet is a struct for package private usage.
 // To create a new 'SymbolSet' instance, use NewSymbolSet
+//
+// A SymbolSet is not modified after initialization (the symbols should not be changed after the symbol set has been created), and it is safe to share between goroutines.
 type SymbolSet struct {
 	Name    string
 	Type    Type
 	Symbols []Symbol
 
+	// metadata, as declared in the .sym file (optional)
+	Language    string // BCP 47 language tag
+	Version     string
+	Description string
+	License     string
+	Source      string
+
+	// StressPlacement is the declared stress placement convention. If undefined, stress is filtered according to the Type.
+	StressPlacement StressPlacement
+
+	// NormalizationForm is the Unicode normalization form for IPA strings (no normalization by default, see FormNone)
+	NormalizationForm NormalizationForm
+
+	// DisabledRules are the well-formedness rules that should not be checked by CheckWellFormedness
+	DisabledRules []Rule
+
 	// to check if the struct has been initialized properly
 	isInit bool
 
@@ -105,23 +150,54 @@ type SymbolSet struct {
 	ipaNonSyllabicRe *regexp.Regexp
 
 	PhonemeDelimiter          Symbol
+	phonemeDelimiters         []Symbol
 	phonemeDelimiterRe        *regexp.Regexp
 	repeatedPhonemeDelimiters *regexp.Regexp
+
+	// indexes of the symbols (by symbol string, and by IPA string) in Symbols
+	symbolIndex map[string]int
+	ipaIndex    map[string]int
+
+	// tries for splitting transcriptions without phoneme delimiters
+	symbolTrie *trie
+	ipaTrie    *trie
+
+	// ambiguous segmentations in the symbol inventory, if there is no phoneme delimiter
+	ambiguities []Ambiguity
+
+	// Aliases are alternative (or deprecated) spellings of symbols, accepted as input and normalized into the canonical form
+	Aliases       []Alias
+	symbolAliases map[string]Alias
+	ipaAliases    map[string]Alias
+
+	// Modifiers are symbols that can be attached to base symbols, such as length marks and diacritics
+	Modifiers []Modifier
+	// symbols combined from base symbols and modifiers (not included in Symbols)
+	composed []Symbol
+
+	// characters in IPA input that are replaced by an equivalent character used in the IPA inventory (e.g., tie bars)
+	ipaEquivalents map[rune]rune
+
+	// precompiled stress/accent filters
+	filters filters
+
+	// symbol set tests (TEST lines in the .sym file)
+	testLines []string
+
+	// line order of the .sym file the symbol set was loaded from, if any (used when writing the symbol set back to file)
+	layout []symLine
 }
 
 // ValidSymbol checks if a string is a valid symbol or not
 func (ss SymbolSet) ValidSymbol(symbol string) bool {
-	return contains(ss.Symbols, symbol)
+	_, ok := ss.symbolIndex[symbol]
+	return ok
 }
 
 // ValidIPASymbol checks if a string is a valid symbol or not
 func (ss SymbolSet) ValidInternalIPASymbol(symbol string) bool {
-	for _, s := range ss.Symbols {
-		if s.IPA.String == symbol {
-			return true
-		}
-	}
-	return false
+	_, ok := ss.ipaIndex[ss.NormalizeIPAInput(symbol)]
+	return ok
 }
 
 // ContainsSymbols checks if a transcription contains a certain phoneme symbol
@@ -140,22 +216,31 @@ func (ss SymbolSet) ContainsSymbols(trans string, symbols []Symbol) (bool, error
 	return false, nil
 }
 
-// Get searches the SymbolSet for a symbol with the given string
+// IsAlias checks if a string is an alias (or a deprecated symbol) in the symbol set
+func (ss SymbolSet) IsAlias(symbol string) bool {
+	_, ok := ss.symbolAliases[symbol]
+	return ok
+}
+
+// Get searches the SymbolSet for a symbol with the given string. Aliases are resolved into the canonical symbol.
 func (ss SymbolSet) Get(symbol string) (Symbol, error) {
-	for _, s := range ss.Symbols {
-		if s.String == symbol {
-			return s, nil
-		}
+	if a, ok := ss.symbolAliases[symbol]; ok {
+		symbol = a.Canonical
+	}
+	if i, ok := ss.symbolIndex[symbol]; ok {
+		return ss.symbolAt(i), nil
 	}
 	return Symbol{}, fmt.Errorf("no symbol /%s/ in symbol set %s", symbol, ss.Name)
 }
 
-// GetFromInternalIPA searches the SymbolSet for a symbol with the given IPA symbol string
+// GetFromInternalIPA searches the SymbolSet for a symbol with the given IPA symbol string. IPA aliases are resolved into the canonical symbol.
 func (ss SymbolSet) GetFromInternalIPA(ipa string) (Symbol, error) {
-	for _, s := range ss.Symbols {
-		if s.IPA.String == ipa {
-			return s, nil
-		}
+	ipa = ss.NormalizeIPAInput(ipa)
+	if a, ok := ss.ipaAliases[ipa]; ok {
+		ipa = a.Canonical
+	}
+	if i, ok := ss.ipaIndex[ipa]; ok {
+		return ss.symbolAt(i), nil
 	}
 	return Symbol{}, fmt.Errorf("no ipa symbol /%s/ in symbol set %s", ipa, ss.Name)
 }
@@ -167,14 +252,15 @@ func (ss SymbolSet) SplitTranscription(input string) ([]string, error) {
 	}
 	delim := ss.phonemeDelimiterRe
 	if delim.FindStringIndex("") != nil {
-		splitted, unknown, err := splitIntoPhonemes(ss.Symbols, input)
-		if err != nil {
-			return []string{}, err
+		for _, ph := range ss.phonemeDelimiters {
+			if len(ph.String) > 0 {
+				return []string{}, fmt.Errorf("symbol set %s has an empty phoneme delimiter, and should not also have a non-empty phoneme delimiter; found /%s/", ss.Name, ph.String)
+			}
 		}
+		splitted, unknown := ss.symbolTrie.split(input)
 		if len(unknown) > 0 {
 			ssErr := UnknownInputSymbol(unknown)
 			return []string{}, ssErr
-			//return []string{}, fmt.Errorf("found unknown phonemes in transcription /%s/: %w", input, unknown)
 		}
 		return splitted, nil
 	}
@@ -195,22 +281,13 @@ func (ss SymbolSet) SplitInternalIPATranscription(input string) ([]string, error
 	if !ss.isInit {
 		panic("symbolSet " + ss.Name + " has not been initialized properly!")
 	}
-	input, err := preFilter(ss, input, IPA)
+	input, err := preFilter(ss, ss.NormalizeIPAInput(input), IPA)
 	if err != nil {
 		return []string{}, err
 	}
 	delim := ss.PhonemeDelimiter.IPA.String
 	if delim == "" {
-		symbols := []Symbol{}
-		for _, s := range ss.Symbols {
-			ipa := s
-			ipa.String = ipa.IPA.String
-			symbols = append(symbols, ipa)
-		}
-		splitted, unknown, err := splitIntoPhonemes(symbols, input)
-		if err != nil {
-			return []string{}, err
-		}
+		splitted, unknown := ss.ipaTrie.split(input)
 		if len(unknown) > 0 {
 			ssErr := UnknownInputSymbol(unknown)
 			return []string{}, ssErr
@@ -220,10 +297,27 @@ func (ss SymbolSet) SplitInternalIPATranscription(input string) ([]string, error
 	return strings.Split(input, delim), nil
 }
 
-// ConvertToInternalIPA maps one input transcription string into an IPA transcription
+// ConvertToInternalIPA maps one input transcription string into an IPA transcription.
+// Phrases are split into words (see SplitPhrase), and each word is filtered and mapped separately, so that filters and stress placement do not cross word boundaries.
 func (ss SymbolSet) ConvertToInternalIPA(trans string) (string, error) {
+	phrase, err := ss.SplitPhrase(trans)
+	if err != nil || len(phrase.Words) < 2 {
+		return ss.convertWordToInternalIPA(trans)
+	}
+	return mapPhrase(phrase, ss.PhonemeDelimiter.IPA.String,
+		func(word []string) (string, error) {
+			return ss.convertWordToInternalIPA(strings.Join(word, ss.PhonemeDelimiter.String))
+		},
+		func(boundary string) (string, error) {
+			symbol, err := ss.Get(boundary)
+			return symbol.IPA.String, err
+		})
+}
+
+// convertWordToInternalIPA maps one input word (or a transcription that is not split into words) into an IPA transcription
+func (ss SymbolSet) convertWordToInternalIPA(trans string) (string, error) {
 	var unknownInputSymbols = []string{}
-	res, err := preFilter(ss, trans, ss.Type)
+	res, err := filterBeforeMapping(ss, trans)
 	if err != nil {
 		return "", err
 	}
@@ -256,13 +350,31 @@ func (ss SymbolSet) ConvertToInternalIPA(trans string) (string, error) {
 	return res, err
 }
 
-// ConvertFromIPA maps one input IPA transcription into the current symbol set
+// ConvertFromInternalIPA maps one input IPA transcription into the current symbol set.
+// Phrases are split into words at word delimiters, pauses and intonation boundaries, and each word is mapped and filtered separately.
 func (ss SymbolSet) ConvertFromInternalIPA(trans string) (string, error) {
-	res := trans
-	splitted, err := ss.SplitInternalIPATranscription(res)
+	splitted, err := ss.SplitInternalIPATranscription(trans)
 	if err != nil {
 		return "", err
 	}
+	phrase := splitPhrase(splitted, func(s string) bool {
+		sym, err := ss.GetFromInternalIPA(s)
+		return err == nil && isWordBoundary(sym.Cat)
+	})
+	if len(phrase.Words) < 2 {
+		return ss.convertWordFromInternalIPA(splitted)
+	}
+	return mapPhrase(phrase, ss.PhonemeDelimiter.String, ss.convertWordFromInternalIPA,
+		func(boundary string) (string, error) {
+			symbol, err := ss.GetFromInternalIPA(boundary)
+			return symbol.String, err
+		})
+}
+
+// convertWordFromInternalIPA maps the IPA symbols of one word (or a transcription that is not split into words) into the current symbol set
+func (ss SymbolSet) convertWordFromInternalIPA(splitted []string) (string, error) {
+	var res string
+	var err error
 	var unknownInputSymbols = []string{}
 	var mapped = make([]string, 0)
 	for _, fromS := range splitted {
@@ -285,6 +397,6 @@ func (ss SymbolSet) ConvertFromInternalIPA(trans string) (string, error) {
 
 	// remove repeated phoneme delimiters, if any
 	res = ss.repeatedPhonemeDelimiters.ReplaceAllString(res, ss.PhonemeDelimiter.String)
-	res, err = postFilter(ss, res, ss.Type)
+	res, err = filterAfterMapping(ss, res)
 	return res, err
 }
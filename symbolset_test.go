@@ -2,7 +2,13 @@ package symbolset
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 )
 
 var fsExpTrans = "Expected: /%v/ got: /%v/"
@@ -30,8 +36,8 @@ func testSymbolSetConvertFromInternalIPA(t *testing.T, ss SymbolSet, input strin
 func Test_NewSymbolSet_WithoutPhonemeDelimiter(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	_, err := NewSymbolSet(name, symbols)
 	if err == nil {
@@ -42,27 +48,30 @@ func Test_NewSymbolSet_WithoutPhonemeDelimiter(t *testing.T) {
 func Test_NewSymbolSet_InputContainsDuplicates(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"a", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "phn delim", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "a", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "phn delim", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
-	_, err := NewSymbolSet(name, symbols)
+	ss, err := NewSymbolSet(name, symbols)
 	// if err == nil {
 	// 	t.Errorf("NewSymbolSet() expected error here")
 	// }
 	if err != nil {
 		t.Errorf("NewSymbolSet() didn't expect error here, found %v", err)
 	}
+	if issues := Lint(ss); len(issues) != 1 || issues[0].Code != LintDuplicateSymbol {
+		t.Errorf("Lint() expected duplicate symbol, found %v", issues)
+	}
 }
 
 func Test_NewSymbolSet_FailOnIncorrectIPAUnicode(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"t", NonSyllabic, "", IPASymbol{"t", "U+0074"}},
-		{"A:", Syllabic, "", IPASymbol{"ɑː", "U+0251:"}},
-		{" ", PhonemeDelimiter, "phn delim", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: "A:", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "ɑː", Unicode: "U+0251:"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "phn delim", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	_, err := NewSymbolSet(name, symbols)
 	if err == nil {
@@ -73,11 +82,11 @@ func Test_NewSymbolSet_FailOnIncorrectIPAUnicode(t *testing.T) {
 func Test_SplitTranscription_Normal1(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "phn delim", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "phn delim", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -98,11 +107,11 @@ func Test_SplitTranscription_Normal1(t *testing.T) {
 func Test_SplitIPATranscription_Normal1(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"t", NonSyllabic, "", IPASymbol{"t", "U+0074"}},
-		{"s", NonSyllabic, "", IPASymbol{"s", "U+0073"}},
-		{"t_s", NonSyllabic, "", IPASymbol{"tS", "U+0074U+0053"}},
-		{" ", PhonemeDelimiter, "phn delim", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "t", Unicode: "U+0074"}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "s", Unicode: "U+0073"}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "tS", Unicode: "U+0074U+0053"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "phn delim", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -123,14 +132,14 @@ func Test_SplitIPATranscription_Normal1(t *testing.T) {
 func Test_SplitIPATranscription_AccentII(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"b", Syllabic, "", IPASymbol{"b", "U+0062"}},
-		{"r", NonSyllabic, "", IPASymbol{"r", "U+0072"}},
-		{"ɑ:", NonSyllabic, "", IPASymbol{"ɑː", "U+0251U+02D0"}},
-		{"k", NonSyllabic, "", IPASymbol{"k", "U+006B"}},
-		{"a", NonSyllabic, "", IPASymbol{"a", "U+0061"}},
-		{"\"\"", NonSyllabic, "", IPASymbol{"ˈ̀", "U+02C8U+0300"}},
-		{" ", PhonemeDelimiter, "phn delim", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "syll delim", IPASymbol{".", "U+002E"}},
+		{String: "b", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "r", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "r", Unicode: "U+0072"}},
+		{String: "ɑ:", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "ɑː", Unicode: "U+0251U+02D0"}},
+		{String: "k", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "k", Unicode: "U+006B"}},
+		{String: "a", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "\"\"", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "ˈ̀", Unicode: "U+02C8U+0300"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "phn delim", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "syll delim", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -151,11 +160,11 @@ func Test_SplitIPATranscription_AccentII(t *testing.T) {
 func Test_SplitTranscription_EmptyPhonemeDelmiter1(t *testing.T) {
 	name := "ss"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -175,13 +184,13 @@ func Test_SplitTranscription_EmptyPhonemeDelmiter1(t *testing.T) {
 func Test_SplitTranscription_FailWithUnknownSymbols_EmptyDelim(t *testing.T) {
 	name := "sampa"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"b", NonSyllabic, "", IPASymbol{"", ""}},
-		{"N", NonSyllabic, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"\"", Stress, "", IPASymbol{"", ""}},
-		{"\"\"", Stress, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "N", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -202,13 +211,13 @@ func Test_SplitTranscription_FailWithUnknownSymbols_EmptyDelim(t *testing.T) {
 func Test_SplitTranscription_NoFailWithUnknownSymbols_NonEmptyDelim(t *testing.T) {
 	name := "sampa"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"b", NonSyllabic, "", IPASymbol{"", ""}},
-		{"N", NonSyllabic, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"\"", Stress, "", IPASymbol{"", ""}},
-		{"\"\"", Stress, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "N", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -228,13 +237,13 @@ func Test_SplitTranscription_NoFailWithUnknownSymbols_NonEmptyDelim(t *testing.T
 func Test_ValidSymbol1(t *testing.T) {
 	name := "sampa"
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"b", NonSyllabic, "", IPASymbol{"", ""}},
-		{"N", NonSyllabic, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"\"", Stress, "", IPASymbol{"", ""}},
-		{"\"\"", Stress, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "N", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet(name, symbols)
 	if err != nil {
@@ -263,15 +272,15 @@ func Test_ValidSymbol1(t *testing.T) {
 
 func Test_ConvertToInternalIPA(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"b", NonSyllabic, "", IPASymbol{"b", "U+0062"}},
-		{"r", NonSyllabic, "", IPASymbol{"r", "U+0072"}},
-		{"k", NonSyllabic, "", IPASymbol{"k", "U+006B"}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"A:", Syllabic, "", IPASymbol{"ɑː", "U+0251U+02D0"}},
-		{"$", SyllableDelimiter, "", IPASymbol{".", "U+002E"}},
-		{"\"", Stress, "", IPASymbol{"\u02C8", "U+02C8"}},
-		{"\"\"", Stress, "", IPASymbol{"\u02C8\u0300", "U+02C8U+0300"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "r", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "r", Unicode: "U+0072"}},
+		{String: "k", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "k", Unicode: "U+006B"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "A:", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "ɑː", Unicode: "U+0251U+02D0"}},
+		{String: "$", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8", Unicode: "U+02C8"}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8\u0300", Unicode: "U+02C8U+0300"}},
 	}
 	ss, err := NewSymbolSet("sampa", symbols)
 	if err != nil {
@@ -305,15 +314,15 @@ func Test_ConvertToInternalIPA(t *testing.T) {
 
 func Test_ConvertFromInternalIPA(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"b", NonSyllabic, "", IPASymbol{"b", "U+0062"}},
-		{"r", NonSyllabic, "", IPASymbol{"r", "U+0072"}},
-		{"k", NonSyllabic, "", IPASymbol{"k", "U+006B"}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"A:", Syllabic, "", IPASymbol{"ɑː", "U+0251U+02D0"}},
-		{"$", SyllableDelimiter, "", IPASymbol{".", "U+002E"}},
-		{"\"", Stress, "", IPASymbol{"\u02C8", "U+02C8"}},
-		{"\"\"", Stress, "", IPASymbol{"\u02C8\u0300", "U+02C8U+0300"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "r", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "r", Unicode: "U+0072"}},
+		{String: "k", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "k", Unicode: "U+006B"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "A:", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "ɑː", Unicode: "U+0251U+02D0"}},
+		{String: "$", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8", Unicode: "U+02C8"}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8\u0300", Unicode: "U+02C8U+0300"}},
 	}
 	ss, err := NewSymbolSet("sampa", symbols)
 	if err != nil {
@@ -364,7 +373,7 @@ func Test_LoadSymbolSetsFromDir(t *testing.T) {
 	for _, ss := range symbolsets {
 		ssNames = append(ssNames, ss.Name)
 	}
-	expN := 9
+	expN := 11
 	if len(symbolsets) != expN {
 		t.Errorf("Expected %d symbol sets in folder ./test_data, found %d", expN, len(symbolsets))
 	}
@@ -375,9 +384,9 @@ func Test_LoadSymbolSetsFromDir(t *testing.T) {
 
 func Test_NewSymbolSet_WithCorrectInput1(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"A", "U+0041"}},
-		{"p", NonSyllabic, "", IPASymbol{"P", "U+0050"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "P", Unicode: "U+0050"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	_, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -399,10 +408,10 @@ func Test_loadSymbolSet_NST2IPA_SV(t *testing.T) {
 
 func Test_MapTranscription_Sampa2Ipa_Simple(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"p", NonSyllabic, "", IPASymbol{"p", "U+0070"}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"$", SyllableDelimiter, "", IPASymbol{".", "U+002E"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "p", Unicode: "U+0070"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -554,10 +563,10 @@ func Test_loadSymbolSet_IPA2NST_NB(t *testing.T) {
 
 func Test_NewSymbolSet_IPADuplicates_ConvertToInternalIPA(t *testing.T) {
 	symbols := []Symbol{
-		{"i", Syllabic, "", IPASymbol{"I", "U+0049"}},
-		{"i3", Syllabic, "", IPASymbol{"I", "U+0049"}},
-		{"p", NonSyllabic, "", IPASymbol{"P", "U+0050"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"_", "U+005F"}},
+		{String: "i", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "I", Unicode: "U+0049"}},
+		{String: "i3", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "I", Unicode: "U+0049"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "P", Unicode: "U+0050"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "_", Unicode: "U+005F"}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -570,10 +579,10 @@ func Test_NewSymbolSet_IPADuplicates_ConvertToInternalIPA(t *testing.T) {
 
 func Test_NewSymbolSet_IPADuplicates_ConvertFromInternalIPA(t *testing.T) {
 	symbols := []Symbol{
-		{"i", Syllabic, "", IPASymbol{"I", "U+0049"}},
-		{"i3", Syllabic, "", IPASymbol{"I", "U+0049"}},
-		{"p", NonSyllabic, "", IPASymbol{"P", "U+0050"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "i", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "I", Unicode: "U+0049"}},
+		{String: "i3", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "I", Unicode: "U+0049"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "P", Unicode: "U+0050"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -586,9 +595,9 @@ func Test_NewSymbolSet_IPADuplicates_ConvertFromInternalIPA(t *testing.T) {
 
 func Test_NewSymbolSet_FailIfLacksPhonemeDelimiter(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"A", "U+0041"}},
-		{"p", NonSyllabic, "", IPASymbol{"P", "U+0050"}},
-		{" ", NonSyllabic, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "P", Unicode: "U+0050"}},
+		{String: " ", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	_, err := NewSymbolSet("test", symbols)
 	if err == nil {
@@ -598,10 +607,10 @@ func Test_NewSymbolSet_FailIfLacksPhonemeDelimiter(t *testing.T) {
 
 func Test_ConvertToInternalIPA_Sampa2Ipa_Simple(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"p", NonSyllabic, "", IPASymbol{"p", "U+0070"}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"$", SyllableDelimiter, "", IPASymbol{".", "U+002E"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "p", Unicode: "U+0070"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -621,12 +630,12 @@ func Test_ConvertToInternalIPA_Sampa2Ipa_Simple(t *testing.T) {
 
 func Test_ConvertToInternalIPA_Sampa2Ipa_WithSwedishStress_1(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"p", NonSyllabic, "", IPASymbol{"p", "U+0070"}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"$", SyllableDelimiter, "", IPASymbol{".", "U+002E"}},
-		{"\"", Stress, "", IPASymbol{"\u02C8", "U+02C8"}},
-		{"\"\"", Stress, "", IPASymbol{"\u02C8\u0300", "U+02C8U+0300"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "p", Unicode: "U+0070"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8", Unicode: "U+02C8"}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8\u0300", Unicode: "U+02C8U+0300"}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -646,15 +655,15 @@ func Test_ConvertToInternalIPA_Sampa2Ipa_WithSwedishStress_1(t *testing.T) {
 
 func Test_ConvertToInternalIPA_Sampa2Ipa_WithSwedishStress_2(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"b", NonSyllabic, "", IPASymbol{"b", "U+0062"}},
-		{"r", NonSyllabic, "", IPASymbol{"r", "U+0072"}},
-		{"k", NonSyllabic, "", IPASymbol{"k", "U+006B"}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"A:", Syllabic, "", IPASymbol{"ɑː", "U+0251U+02D0"}},
-		{"$", SyllableDelimiter, "", IPASymbol{".", "U+002E"}},
-		{"\"", Stress, "", IPASymbol{"\u02C8", "U+02C8"}},
-		{"\"\"", Stress, "", IPASymbol{"\u02C8\u0300", "U+02C8U+0300"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "r", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "r", Unicode: "U+0072"}},
+		{String: "k", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "k", Unicode: "U+006B"}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "A:", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "ɑː", Unicode: "U+0251U+02D0"}},
+		{String: "$", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8", Unicode: "U+02C8"}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8\u0300", Unicode: "U+02C8U+0300"}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -674,13 +683,13 @@ func Test_ConvertToInternalIPA_Sampa2Ipa_WithSwedishStress_2(t *testing.T) {
 
 func Test_ConvertToInternalIPA_FailWithUnknownSymbols_NonEmptyDelim(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"a", "U+0061"}},
-		{"b", NonSyllabic, "", IPASymbol{"b", "U+0062"}},
-		{"ŋ", NonSyllabic, "", IPASymbol{"N", "U+004E"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"$", "U+0024"}},
-		{"\"", Stress, "", IPASymbol{"\u02C8", "U+02C8"}},
-		{"\"\"", Stress, "", IPASymbol{"\u02C8\u0300", "U+02C8U+0300"}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "ŋ", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "N", Unicode: "U+004E"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "$", Unicode: "U+0024"}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8", Unicode: "U+02C8"}},
+		{String: "\"\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "\u02C8\u0300", Unicode: "U+02C8U+0300"}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -696,9 +705,9 @@ func Test_ConvertToInternalIPA_FailWithUnknownSymbols_NonEmptyDelim(t *testing.T
 
 func Test_Get(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"A", "U+0041"}},
-		{"P", NonSyllabic, "", IPASymbol{"p", "U+0070"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "P", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "p", Unicode: "U+0070"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -734,9 +743,9 @@ func Test_Get(t *testing.T) {
 
 func Test_GetFromInternalIPA(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"A", "U+0041"}},
-		{"P", NonSyllabic, "", IPASymbol{"p", "U+0070"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "P", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "p", Unicode: "U+0070"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	ss, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -772,10 +781,10 @@ func Test_GetFromInternalIPA(t *testing.T) {
 
 func Test_NewSymbolSet_DontFailIfIPAContainsDuplicates(t *testing.T) {
 	symbols := []Symbol{
-		{"a", NonSyllabic, "", IPASymbol{"A", "U+0041"}},
-		{"A", Syllabic, "", IPASymbol{"A", "U+0041"}},
-		{"p", NonSyllabic, "", IPASymbol{"P", "U+0050"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "a", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "A", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "P", Unicode: "U+0050"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	_, err := NewSymbolSet("test", symbols)
 	if err != nil {
@@ -785,13 +794,261 @@ func Test_NewSymbolSet_DontFailIfIPAContainsDuplicates(t *testing.T) {
 
 func Test_NewSymbolSet_FailIPAContainsWhitespace(t *testing.T) {
 	symbols := []Symbol{
-		{"a", NonSyllabic, "", IPASymbol{"A", "U+0041"}},
-		{"A", Syllabic, "", IPASymbol{"A", "U+0041"}},
-		{"p", NonSyllabic, "", IPASymbol{"P", "U+0050"}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"P ", "U+0050U+0020"}},
+		{String: "a", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "A", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "A", Unicode: "U+0041"}},
+		{String: "p", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "P", Unicode: "U+0050"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "P ", Unicode: "U+0050U+0020"}},
 	}
 	_, err := NewSymbolSet("test", symbols)
 	if err == nil {
 		t.Errorf("expected error for IPA white space here : %v", err)
 	}
 }
+
+func Test_ReadSymbolSet(t *testing.T) {
+	input := strings.Join([]string{
+		header,
+		"TYPE	SAMPA",
+		"sil	i:	iː	U+0069U+02D0	Syllabic",
+		"bok	b	b	U+0062	NonSyllabic",
+		"phoneme delimiter	 			PhonemeDelimiter",
+		"TEST	ACCEPT	SYMBOLS	b i:",
+	}, "\n")
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Errorf("ReadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	if ss.Name != "test" {
+		t.Errorf(fsExp, "test", ss.Name)
+	}
+	if ss.Type != SAMPA {
+		t.Errorf(fsExp, SAMPA, ss.Type)
+	}
+	if len(ss.Symbols) != 3 {
+		t.Errorf("Expected 3 symbols, got %d", len(ss.Symbols))
+	}
+
+	_, err = ReadSymbolSet("test", strings.NewReader("SYMBOL	IPA\n"))
+	if err == nil {
+		t.Errorf("ReadSymbolSet() expected error for invalid header")
+	}
+}
+
+func Test_LoadSymbolSetsFromFS(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"sv-se_ws-sampa.sym", "en-us_cmu.sym"} {
+		data, err := os.ReadFile(filepath.Join("test_data", name))
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			return
+		}
+		fsys["symbolsets/"+name] = &fstest.MapFile{Data: data}
+	}
+	fsys["symbolsets/README.md"] = &fstest.MapFile{Data: []byte("not a symbol set")}
+	fsys["symbolsets/old/invalid.sym"] = &fstest.MapFile{Data: []byte("not a symbol set")}
+
+	sets, err := LoadSymbolSetsFromFS(fsys, "symbolsets")
+	if err != nil {
+		t.Errorf("LoadSymbolSetsFromFS() didn't expect error here : %v", err)
+		return
+	}
+	if len(sets) != 2 {
+		t.Errorf("Expected 2 symbol sets, got %d", len(sets))
+	}
+	if ss, ok := sets["en-us_cmu"]; !ok || ss.Type != CMU {
+		t.Errorf("Expected symbol set en-us_cmu of type CMU, got %v", sets)
+	}
+
+	_, err = LoadSymbolSetsFromFS(fsys, "symbolsets/old")
+	if err == nil {
+		t.Errorf("LoadSymbolSetsFromFS() expected error for invalid symbol set")
+	}
+
+	ss, err := LoadSymbolSetFS(fsys, "symbolsets/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSetFS() didn't expect error here : %v", err)
+		return
+	}
+	if ss.Name != "sv-se_ws-sampa" {
+		t.Errorf(fsExp, "sv-se_ws-sampa", ss.Name)
+	}
+}
+
+// lexicon-scale input for the lookup benchmarks
+func benchmarkNSTLexicon(n int) []string {
+	var res = make([]string, n)
+	for i := range res {
+		res[i] = benchmarkNSTTranscriptions[i%len(benchmarkNSTTranscriptions)]
+	}
+	return res
+}
+
+func Benchmark_ConvertToInternalIPA_100k(b *testing.B) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		b.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	lexicon := benchmarkNSTLexicon(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, trans := range lexicon {
+			if _, err := ss.ConvertToInternalIPA(trans); err != nil {
+				b.Fatalf("ConvertToInternalIPA() didn't expect error here : %v", err)
+			}
+		}
+	}
+}
+
+func Benchmark_ConvertFromInternalIPA_100k(b *testing.B) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		b.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	var lexicon []string
+	for _, trans := range benchmarkNSTLexicon(100000) {
+		ipa, err := ss.ConvertToInternalIPA(trans)
+		if err != nil {
+			b.Fatalf("ConvertToInternalIPA() didn't expect error here : %v", err)
+		}
+		lexicon = append(lexicon, ipa)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, trans := range lexicon {
+			if _, err := ss.ConvertFromInternalIPA(trans); err != nil {
+				b.Fatalf("ConvertFromInternalIPA() didn't expect error here : %v", err)
+			}
+		}
+	}
+}
+
+func Benchmark_ConvertToInternalIPA_100k_Parallel(b *testing.B) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		b.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	lexicon := benchmarkNSTLexicon(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, trans := range lexicon {
+				if _, err := ss.ConvertToInternalIPA(trans); err != nil {
+					b.Errorf("ConvertToInternalIPA() didn't expect error here : %v", err)
+					return
+				}
+			}
+		}
+	})
+}
+
+func Test_SymbolSet_Concurrent(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		t.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	var expect []string
+	for _, trans := range benchmarkNSTTranscriptions {
+		ipa, err := ss.ConvertToInternalIPA(trans)
+		if err != nil {
+			t.Fatalf("ConvertToInternalIPA() didn't expect error here : %v", err)
+		}
+		expect = append(expect, ipa)
+	}
+
+	// the symbol set is shared (read-only) by all goroutines
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				for i, trans := range benchmarkNSTTranscriptions {
+					ipa, err := ss.ConvertToInternalIPA(trans)
+					if err == nil && ipa != expect[i] {
+						err = fmt.Errorf("expected /%s/, got /%s/", expect[i], ipa)
+					}
+					if err == nil {
+						_, err = ss.ConvertFromInternalIPA(ipa)
+					}
+					if err == nil && !ss.ValidSymbol("A:") {
+						err = fmt.Errorf("expected A: to be a valid symbol")
+					}
+					if err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func Test_SymbolCatFromString(t *testing.T) {
+	for cat := Syllabic; cat <= IntonationBoundary; cat++ {
+		result, err := symbolCatFromString(cat.String())
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != cat {
+			t.Errorf(fsExp, cat, result)
+		}
+	}
+	if _, err := symbolCatFromString("Intonation"); err == nil {
+		t.Errorf("expected error for invalid category")
+	}
+}
+
+func Test_LoadSymbolSet_ToneCategories(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/cmn_x-sampa-tone.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	for s, exp := range map[string]SymbolCat{"_1": Tone, ":": Length, "_h": Diacritic, "sil": Pause, "||": IntonationBoundary} {
+		sym, err := ss.Get(s)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if sym.Cat != exp {
+			t.Errorf("/%s/: "+fsExp, s, exp, sym.Cat)
+		}
+	}
+
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{`" p _h a _1 . m a _4`, "ˈpʰa˥.ma˥˩"},
+		{`" t a ~ N`, "ˈta\u0303ŋ"},
+		{`m a _1 sil " n i _3 || l a : _4`, "ma˥(.)ˈni˨˩˦‖laː˥˩"},
+	}
+	for _, test := range tests {
+		result, err := ss.ConvertToInternalIPA(test.input)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if result != test.expect {
+			t.Errorf(fsExp, test.expect, result)
+		}
+		back, err := ss.ConvertFromInternalIPA(result)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if back != test.input {
+			t.Errorf(fsExp, test.input, back)
+		}
+	}
+}
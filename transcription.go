@@ -0,0 +1,225 @@
+package symbolset
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Transcription is a parsed transcription, made up of words, syllables, phonemes, stress and boundaries.
+// To create a Transcription from a string, use SymbolSet.Parse.
+type Transcription struct {
+	Words []Word
+}
+
+// Word is a parsed word, made up of syllables
+type Word struct {
+	Syllables []Syllable
+
+	// Pauses holds the pause and intonation boundary symbols following the word, if any. A word preceded by a pause at the start of the transcription has no syllables.
+	Pauses []Symbol
+}
+
+// Syllable is a parsed syllable. If the input transcription has no syllable delimiters, each word (or morpheme/compound part) will be parsed as one syllable.
+type Syllable struct {
+	// Boundary is the category of the delimiter preceding the syllable (SyllableDelimiter, MorphemeDelimiter or CompoundDelimiter). The first syllable in each word has boundary WordDelimiter.
+	Boundary SymbolCat
+
+	// Stress holds the stress and accent symbols of the syllable, if any
+	Stress []Symbol
+
+	// Phonemes holds the syllabic and non-syllabic phonemes of the syllable, along with any tone symbols, length marks and diacritics, in input order
+	Phonemes []Symbol
+
+	// index of the phoneme preceded by the stress in the input transcription (used for syllables with more than one syllabic phoneme)
+	stressPos int
+}
+
+// String returns the syllable's symbols, stress first, separated by space
+func (s Syllable) String() string {
+	var res []string
+	for _, sym := range s.Stress {
+		res = append(res, sym.String)
+	}
+	for _, sym := range s.Phonemes {
+		res = append(res, sym.String)
+	}
+	return strings.Join(res, " ")
+}
+
+// Syllables returns all syllables in the transcription
+func (t Transcription) Syllables() []Syllable {
+	var res []Syllable
+	for _, w := range t.Words {
+		res = append(res, w.Syllables...)
+	}
+	return res
+}
+
+// Parse splits the input transcription into words, syllables, stress and phonemes, using the symbol categories of the symbol set.
+// Pauses and intonation boundaries end the current word, and are kept in Word.Pauses.
+func (ss SymbolSet) Parse(trans string) (Transcription, error) {
+	res, err := filterBeforeMapping(ss, trans)
+	if err != nil {
+		return Transcription{}, err
+	}
+	splitted, err := ss.SplitTranscription(res)
+	if err != nil {
+		return Transcription{}, err
+	}
+
+	var unknownInputSymbols = []string{}
+	var words []Word
+	var word Word
+	var syll = Syllable{Boundary: WordDelimiter}
+	closeSyllable := func(nextBoundary SymbolCat) {
+		if len(syll.Stress) > 0 || len(syll.Phonemes) > 0 {
+			word.Syllables = append(word.Syllables, syll)
+			syll = Syllable{Boundary: nextBoundary}
+		} else if len(word.Syllables) > 0 {
+			syll.Boundary = nextBoundary
+		}
+	}
+	for _, phn := range splitted {
+		symbol, err := ss.Get(phn)
+		if err != nil {
+			if !slices.Contains(unknownInputSymbols, phn) {
+				unknownInputSymbols = append(unknownInputSymbols, phn)
+			}
+			continue
+		}
+		switch symbol.Cat {
+		case Stress:
+			if len(syll.Stress) == 0 {
+				syll.stressPos = len(syll.Phonemes)
+			}
+			syll.Stress = append(syll.Stress, symbol)
+		case Syllabic, NonSyllabic, Tone, Length, Diacritic:
+			syll.Phonemes = append(syll.Phonemes, symbol)
+		case SyllableDelimiter, MorphemeDelimiter, CompoundDelimiter:
+			closeSyllable(symbol.Cat)
+		case WordDelimiter, Pause, IntonationBoundary:
+			closeSyllable(WordDelimiter)
+			syll.Boundary = WordDelimiter
+			if len(word.Syllables) > 0 {
+				words = append(words, word)
+			}
+			word = Word{}
+			if isProsodicBoundary(symbol.Cat) {
+				if len(words) == 0 {
+					words = append(words, Word{})
+				}
+				words[len(words)-1].Pauses = append(words[len(words)-1].Pauses, symbol)
+			}
+		}
+	}
+	if len(unknownInputSymbols) > 0 {
+		return Transcription{}, UnknownInputSymbol(unknownInputSymbols)
+	}
+	closeSyllable(WordDelimiter)
+	if len(word.Syllables) > 0 {
+		words = append(words, word)
+	}
+	return Transcription{Words: words}, nil
+}
+
+// firstNonEmptySymbol returns the first symbol with a non-empty string in the specified category
+func (ss SymbolSet) firstNonEmptySymbol(cat SymbolCat) (Symbol, bool) {
+	for _, s := range ss.Symbols {
+		if s.Cat == cat && len(s.String) > 0 {
+			return s, true
+		}
+	}
+	return Symbol{}, false
+}
+
+// Render maps the transcription into the target symbol set, and returns the resulting transcription string.
+// Symbols are mapped using their IPA representation.
+// Boundaries missing in the target symbol set are rendered using the target's syllable delimiter, if any.
+// Words followed by pauses or intonation boundaries are separated by these instead of the target's word delimiter.
+func (t Transcription) Render(target SymbolSet) (string, error) {
+	if !target.isInit {
+		panic("symbolSet " + target.Name + " has not been initialized properly!")
+	}
+	var unknownInputSymbols = []string{}
+	mapSymbol := func(sym Symbol) (string, bool) {
+		if len(sym.IPA.String) == 0 {
+			return "", false
+		}
+		mapped, err := target.GetFromInternalIPA(sym.IPA.String)
+		if err != nil {
+			if !slices.Contains(unknownInputSymbols, sym.IPA.String) {
+				unknownInputSymbols = append(unknownInputSymbols, sym.IPA.String)
+			}
+			return "", false
+		}
+		return mapped.String, len(mapped.String) > 0
+	}
+
+	wordDelim, hasWordDelim := target.firstNonEmptySymbol(WordDelimiter)
+
+	var words []string
+	for i, w := range t.Words {
+		if i > 0 && len(t.Words[i-1].Pauses) == 0 {
+			if !hasWordDelim {
+				return "", fmt.Errorf("no word delimiter defined in symbol set %s", target.Name)
+			}
+			words = append(words, wordDelim.String)
+		}
+		var res []string
+		for i, syll := range w.Syllables {
+			if i > 0 {
+				delim, ok := target.firstNonEmptySymbol(syll.Boundary)
+				if !ok {
+					delim, ok = target.firstNonEmptySymbol(SyllableDelimiter)
+				}
+				if ok {
+					res = append(res, delim.String)
+				}
+			}
+			var stress []string
+			for _, sym := range syll.Stress {
+				if s, ok := mapSymbol(sym); ok {
+					stress = append(stress, s)
+				}
+			}
+			stressPos := 0
+			if nSyllabic(syll.Phonemes) > 1 {
+				stressPos = syll.stressPos
+			}
+			for j, sym := range syll.Phonemes {
+				if j == stressPos {
+					res = append(res, stress...)
+				}
+				if s, ok := mapSymbol(sym); ok {
+					res = append(res, s)
+				}
+			}
+			if stressPos >= len(syll.Phonemes) {
+				res = append(res, stress...)
+			}
+		}
+		for _, sym := range w.Pauses {
+			if s, ok := mapSymbol(sym); ok {
+				res = append(res, s)
+			}
+		}
+		words = append(words, strings.Join(res, target.PhonemeDelimiter.String))
+	}
+	if len(unknownInputSymbols) > 0 {
+		return "", UnknownInputSymbol(unknownInputSymbols)
+	}
+	res := strings.Join(words, target.PhonemeDelimiter.String)
+	res = target.repeatedPhonemeDelimiters.ReplaceAllString(res, target.PhonemeDelimiter.String)
+	return filterAfterMapping(target, res)
+}
+
+func nSyllabic(symbols []Symbol) int {
+	n := 0
+	for _, s := range symbols {
+		if s.Cat == Syllabic {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,216 @@
+package symbolset
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Parse_CMU(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	trans, err := ss.Parse("P L AE1 $ T AX $ P UH2 S")
+	if err != nil {
+		t.Errorf("Parse() didn't expect error here : %v", err)
+		return
+	}
+	if len(trans.Words) != 1 {
+		t.Errorf("Expected %d words, got %d", 1, len(trans.Words))
+		return
+	}
+	sylls := trans.Syllables()
+	var result []string
+	for _, syll := range sylls {
+		result = append(result, syll.String())
+	}
+	testEqStrings(t, []string{"1 P L AE", "T AX", "2 P UH S"}, result)
+	if sylls[1].Boundary != SyllableDelimiter {
+		t.Errorf(fsExp, SyllableDelimiter, sylls[1].Boundary)
+	}
+}
+
+func Test_Parse_UnknownSymbol(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	_, err = ss.Parse("\" b O Q")
+	if err == nil {
+		t.Errorf("Parse() expected error here")
+	}
+}
+
+func Test_Render(t *testing.T) {
+	cmu, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	ws, err := LoadSymbolSet("test_data/en-us_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	trans, err := cmu.Parse("P L AE1 $ T AX $ P UH2 S")
+	if err != nil {
+		t.Errorf("Parse() didn't expect error here : %v", err)
+		return
+	}
+	result, err := trans.Render(ws)
+	if err != nil {
+		t.Errorf("Render() didn't expect error here : %v", err)
+		return
+	}
+	expect := "' p l { . t @ . % p U s"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+
+	trans, err = ws.Parse(expect)
+	if err != nil {
+		t.Errorf("Parse() didn't expect error here : %v", err)
+		return
+	}
+	result, err = trans.Render(cmu)
+	if err != nil {
+		t.Errorf("Render() didn't expect error here : %v", err)
+		return
+	}
+	expect = "P L AE1 $ T AX $ P UH2 S"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+}
+
+func Test_Render_AccentII(t *testing.T) {
+	ws, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	nst, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	trans, err := ws.Parse("\"\" b r A: . k a")
+	if err != nil {
+		t.Errorf("Parse() didn't expect error here : %v", err)
+		return
+	}
+	result, err := trans.Render(nst)
+	if err != nil {
+		t.Errorf("Render() didn't expect error here : %v", err)
+		return
+	}
+	expect := "\"\"brA:$ka"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+}
+
+func Test_ParseAndRender_MultipleWords(t *testing.T) {
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "a", Unicode: "U+0061"}},
+		{String: "b", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "b", Unicode: "U+0062"}},
+		{String: "k", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "k", Unicode: "U+006B"}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: ".", Unicode: "U+002E"}},
+		{String: "-", Cat: CompoundDelimiter, Desc: "", IPA: IPASymbol{String: "-", Unicode: "U+002D"}},
+		{String: "#", Cat: WordDelimiter, Desc: "", IPA: IPASymbol{String: "#", Unicode: "U+0023"}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "ˈ", Unicode: "U+02C8"}},
+	}
+	ss1, err := NewSymbolSet("sampa1", symbols)
+	if err != nil {
+		t.Errorf("NewSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	ss2, err := NewSymbolSet("sampa2", append(symbols[0:5:5], symbols[7]))
+	if err != nil {
+		t.Errorf("NewSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+
+	trans, err := ss1.Parse("\" b a . k a - b a # \" k a")
+	if err != nil {
+		t.Errorf("Parse() didn't expect error here : %v", err)
+		return
+	}
+	if len(trans.Words) != 2 {
+		t.Errorf("Expected %d words, got %d", 2, len(trans.Words))
+		return
+	}
+	if b := trans.Words[0].Syllables[2].Boundary; b != CompoundDelimiter {
+		t.Errorf(fsExp, CompoundDelimiter, b)
+	}
+
+	result, err := trans.Render(ss1)
+	if err != nil {
+		t.Errorf("Render() didn't expect error here : %v", err)
+		return
+	}
+	expect := "\" b a . k a - b a # \" k a"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+
+	// no word delimiter in ss2
+	_, err = trans.Render(ss2)
+	if err == nil {
+		t.Errorf("Render() expected error here")
+	}
+
+	// no compound delimiter in ss2
+	trans.Words = trans.Words[0:1]
+	result, err = trans.Render(ss2)
+	if err != nil {
+		t.Errorf("Render() didn't expect error here : %v", err)
+		return
+	}
+	expect = "\" b a . k a . b a"
+	if result != expect {
+		t.Errorf(fsExpTrans, expect, result)
+	}
+}
+
+func Test_Parse_Pauses(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/cmn_x-sampa-tone.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	input := "sil \" m a _1 . p _h a : _4 || n i # l a sil"
+	trans, err := ss.Parse(input)
+	if err != nil {
+		t.Errorf("Parse() didn't expect error here : %v", err)
+		return
+	}
+	if len(trans.Words) != 4 {
+		t.Errorf("Expected %d words, got %d", 4, len(trans.Words))
+		return
+	}
+	for i, exp := range []string{"sil", "||", "", "sil"} {
+		var pauses []string
+		for _, sym := range trans.Words[i].Pauses {
+			pauses = append(pauses, sym.String)
+		}
+		if got := strings.Join(pauses, " "); got != exp {
+			t.Errorf("word %d: "+fsExp, i, exp, got)
+		}
+	}
+	if s := trans.Words[1].Syllables[1].String(); s != "p _h a : _4" {
+		t.Errorf(fsExp, "p _h a : _4", s)
+	}
+
+	result, err := trans.Render(ss)
+	if err != nil {
+		t.Errorf("Render() didn't expect error here : %v", err)
+		return
+	}
+	if result != input {
+		t.Errorf(fsExpTrans, input, result)
+	}
+}
@@ -0,0 +1,87 @@
+package symbolset
+
+import "unicode/utf8"
+
+// trie is a prefix tree of symbol strings, used for longest match splitting of transcriptions without phoneme delimiters.
+// A trie is built once, when the symbol set is initialized, and is read-only after that.
+type trie struct {
+	root *trieNode
+}
+
+type trieNode struct {
+	children map[byte]*trieNode
+	terminal bool
+}
+
+func newTrie(symbols []string) *trie {
+	t := &trie{root: &trieNode{children: make(map[byte]*trieNode)}}
+	for _, s := range symbols {
+		t.add(s)
+	}
+	return t
+}
+
+func (t *trie) add(symbol string) {
+	if len(symbol) == 0 {
+		return
+	}
+	node := t.root
+	for i := 0; i < len(symbol); i++ {
+		child, ok := node.children[symbol[i]]
+		if !ok {
+			child = &trieNode{children: make(map[byte]*trieNode)}
+			node.children[symbol[i]] = child
+		}
+		node = child
+	}
+	node.terminal = true
+}
+
+// longestMatch returns the byte length of the longest symbol that is a prefix of s, or 0 if there is none
+func (t *trie) longestMatch(s string) int {
+	res := 0
+	node := t.root
+	for i := 0; i < len(s); i++ {
+		child, ok := node.children[s[i]]
+		if !ok {
+			break
+		}
+		node = child
+		if node.terminal {
+			res = i + 1
+		}
+	}
+	return res
+}
+
+// matches returns the byte lengths of all symbols that are prefixes of s, shortest first
+func (t *trie) matches(s string) []int {
+	var res []int
+	node := t.root
+	for i := 0; i < len(s); i++ {
+		child, ok := node.children[s[i]]
+		if !ok {
+			break
+		}
+		node = child
+		if node.terminal {
+			res = append(res, i+1)
+		}
+	}
+	return res
+}
+
+// split splits the transcription into symbols using longest match. Input that doesn't match any known symbol is split into separate runes, and returned as unknown.
+func (t *trie) split(trans string) (phonemes []string, unknown []string) {
+	phonemes = make([]string, 0, len(trans))
+	for len(trans) > 0 {
+		n := t.longestMatch(trans)
+		if n == 0 {
+			_, n = utf8.DecodeRuneInString(trans)
+			unknown = append(unknown, trans[:n])
+		}
+		phonemes = append(phonemes, trans[:n])
+		trans = trans[n:]
+	}
+	return phonemes, unknown
+}
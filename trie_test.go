@@ -0,0 +1,153 @@
+package symbolset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_trie_longestMatch(t *testing.T) {
+	tr := newTrie([]string{"t", "t_s", "s", "", "\"", "\"\""})
+	var tests = []struct {
+		input  string
+		expect int
+	}{
+		{"t_ss", 3},
+		{"t_", 1},
+		{"st", 1},
+		{"\"\"a", 2},
+		{"x", 0},
+		{"", 0},
+	}
+	for _, test := range tests {
+		if got := tr.longestMatch(test.input); got != test.expect {
+			t.Errorf("longestMatch(%s): "+fsExp, test.input, test.expect, got)
+		}
+	}
+}
+
+func Test_trie_split(t *testing.T) {
+	tr := newTrie([]string{"a", "t", "s", "t_s", "\"", "\"\"", "ɑː", "ˈ̀"})
+	var tests = []struct {
+		input   string
+		expect  []string
+		unknown []string
+	}{
+		{"atst_ss", []string{"a", "t", "s", "t_s", "s"}, nil},
+		{"\"\"ta", []string{"\"\"", "t", "a"}, nil},
+		{"ˈ̀tɑːa", []string{"ˈ̀", "t", "ɑː", "a"}, nil},
+		{"taxɑs", []string{"t", "a", "x", "ɑ", "s"}, []string{"x", "ɑ"}},
+	}
+	for _, test := range tests {
+		res, unknown := tr.split(test.input)
+		if !reflect.DeepEqual(test.expect, res) {
+			t.Errorf(vfs, test.expect, res)
+		}
+		if !reflect.DeepEqual(test.unknown, unknown) {
+			t.Errorf(vfs, test.unknown, unknown)
+		}
+	}
+}
+
+// the trie splitter should give the same result as the original recursive splitter
+func Test_trie_CompareWithRecursiveSplitter(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		t.Errorf("LoadSymbolSet() didn't expect error here : %v", err)
+		return
+	}
+	// transcriptions with unknown symbols
+	withUnknown := []string{
+		"\"bOt`Q",
+		"QQ\"ku0rds",
+		"\"fu:$tQa$%bO$l@n",
+		"\"\"sk}:$d@$%spE$l@$rsk@W",
+	}
+	for _, trans := range append(benchmarkNSTTranscriptions, withUnknown...) {
+		expect, expectUnknown, err := splitIntoPhonemes(ss.Symbols, trans)
+		if err != nil {
+			t.Errorf("splitIntoPhonemes() didn't expect error here : %v", err)
+			return
+		}
+		res, unknown := ss.symbolTrie.split(trans)
+		if !reflect.DeepEqual(expect, res) {
+			t.Errorf("/%s/: "+vfs, trans, expect, res)
+		}
+		if len(expectUnknown) == 0 && len(unknown) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(expectUnknown, unknown) {
+			t.Errorf("/%s/: "+vfs, trans, expectUnknown, unknown)
+		}
+	}
+}
+
+var benchmarkNSTTranscriptions = []string{
+	"\"bOt`",
+	"\"ku0rds",
+	"\"\"ku0$d@",
+	"\"\"brA:$ka",
+	"\"fu:$t`a$%bO$l@n",
+	"\"\"sk}:$d@$%spE$l@$rsk@",
+	"a$\"pA:$rat`",
+	"\"\"tak$s@$%mI$nu:$t`",
+	"\"O$ra$N@",
+	"\"\"bE$t@r",
+	"\"x\\u:k",
+	"\"\"s'}:$k@$%hu:s",
+}
+
+func Benchmark_splitIntoPhonemes(b *testing.B) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		b.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, trans := range benchmarkNSTTranscriptions {
+			_, _, err := splitIntoPhonemes(ss.Symbols, trans)
+			if err != nil {
+				b.Fatalf("splitIntoPhonemes() didn't expect error here : %v", err)
+			}
+		}
+	}
+}
+
+func Benchmark_trieSplit(b *testing.B) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		b.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, trans := range benchmarkNSTTranscriptions {
+			_, err := ss.SplitTranscription(trans)
+			if err != nil {
+				b.Fatalf("SplitTranscription() didn't expect error here : %v", err)
+			}
+		}
+	}
+}
+
+func Benchmark_trieSplitInternalIPA(b *testing.B) {
+	ss, err := LoadSymbolSet("test_data/sv-se_nst-xsampa.sym")
+	if err != nil {
+		b.Fatalf("LoadSymbolSet() didn't expect error here : %v", err)
+	}
+	var ipa []string
+	for _, trans := range benchmarkNSTTranscriptions {
+		res, err := ss.ConvertToInternalIPA(trans)
+		if err != nil {
+			b.Fatalf("ConvertToInternalIPA() didn't expect error here : %v", err)
+		}
+		ipa = append(ipa, res)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, trans := range ipa {
+			_, err := ss.SplitInternalIPATranscription(trans)
+			if err != nil {
+				b.Fatalf("SplitInternalIPATranscription() didn't expect error here : %v", err)
+			}
+		}
+	}
+}
@@ -4,9 +4,9 @@ package symbolset
 
 import "fmt"
 
-const _Type_name = "CMUSAMPAIPAOther"
+const _Type_name = "CMUSAMPAIPAInternalIPAOther"
 
-var _Type_index = [...]uint8{0, 3, 8, 11, 16}
+var _Type_index = [...]uint8{0, 3, 8, 11, 22, 27}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
@@ -34,14 +34,14 @@ func testEqSymbols(t *testing.T, expect []Symbol, result []Symbol) {
 
 func Test_buildRegexp1(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"e", Syllabic, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "e", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	re, err := buildRegexp(symbols)
 	if err != nil {
@@ -55,14 +55,14 @@ func Test_buildRegexp1(t *testing.T) {
 
 func Test_buildRegexp2(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"e", Syllabic, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "e", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	re, err := buildRegexpWithGroup(symbols, true, false)
 	if err != nil {
@@ -76,15 +76,15 @@ func Test_buildRegexp2(t *testing.T) {
 
 func Test_buildRegexp3(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"$", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"e", Syllabic, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "e", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	re, err := buildRegexpWithGroup(symbols, false, false)
 	if err != nil {
@@ -98,30 +98,30 @@ func Test_buildRegexp3(t *testing.T) {
 
 func Test_FilterSymbolsByCat(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{"%", Stress, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"$", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"\"", Stress, "", IPASymbol{"", ""}},
-		{"e", Syllabic, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"+", MorphemeDelimiter, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "%", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "e", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "+", Cat: MorphemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	stressE := []Symbol{
-		{"%", Stress, "", IPASymbol{"", ""}},
-		{"\"", Stress, "", IPASymbol{"", ""}},
+		{String: "%", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "\"", Cat: Stress, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	stressR := filterSymbolsByCat(symbols, []SymbolCat{Stress})
 	testEqSymbols(t, stressE, stressR)
 
 	delimE := []Symbol{
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"$", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"+", MorphemeDelimiter, "", IPASymbol{"", ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "+", Cat: MorphemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	delimR := filterSymbolsByCat(symbols, []SymbolCat{SyllableDelimiter, PhonemeDelimiter, MorphemeDelimiter})
 	testEqSymbols(t, delimE, delimR)
@@ -129,15 +129,15 @@ func Test_FilterSymbolsByCat(t *testing.T) {
 
 func Test_contains(t *testing.T) {
 	symbols := []Symbol{
-		{"a", Syllabic, "", IPASymbol{"", ""}},
-		{"t", NonSyllabic, "", IPASymbol{"", ""}},
-		{".", SyllableDelimiter, "", IPASymbol{"", ""}},
-		{"s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"t_s", NonSyllabic, "", IPASymbol{"", ""}},
-		{"$", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{" ", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"", PhonemeDelimiter, "", IPASymbol{"", ""}},
-		{"e", Syllabic, "", IPASymbol{"", ""}},
+		{String: "a", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: ".", Cat: SyllableDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "t_s", Cat: NonSyllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "$", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "", Cat: PhonemeDelimiter, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
+		{String: "e", Cat: Syllabic, Desc: "", IPA: IPASymbol{String: "", Unicode: ""}},
 	}
 	var s string
 
@@ -0,0 +1,314 @@
+package symbolset
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// position-aware validation of transcriptions
+
+// Diagnostic is a problem found in a transcription by SymbolSet.Validate, with the position of the offending token in the input string
+type Diagnostic struct {
+	ErrorType string `json:"error_type"`
+	ErrorCode int    `json:"error_code"`
+
+	// Token is the offending token (a symbol, or a delimiter)
+	Token string `json:"token"`
+
+	// ByteOffset and RuneOffset are the start positions of the token in the input transcription
+	ByteOffset int `json:"byte_offset"`
+	RuneOffset int `json:"rune_offset"`
+
+	Message string `json:"message"`
+
+	// Suggestion is a suggested replacement for the token, if any
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	res := fmt.Sprintf("[%d]: %s: '%s' at position %d: %s", d.ErrorCode, d.ErrorType, d.Token, d.RuneOffset, d.Message)
+	if len(d.Suggestion) > 0 {
+		res += fmt.Sprintf(" (did you mean '%s'?)", d.Suggestion)
+	}
+	return res
+}
+
+// diagnosticAt sets the token and position of the diagnostic
+func diagnosticAt(trans string, t posToken, d Diagnostic) Diagnostic {
+	d.Token = t.s
+	d.ByteOffset = t.offset
+	d.RuneOffset = utf8.RuneCountInString(trans[:t.offset])
+	return d
+}
+
+// posToken is a token in the input transcription, with its byte offset
+type posToken struct {
+	s      string
+	offset int
+	cat    SymbolCat
+	known  bool
+}
+
+// tokenize splits the input into tokens with positions. Phoneme delimiters are not included in the result.
+// Stress symbols attached to the end of a phoneme (e.g., CMU: AE1) are split into separate tokens.
+func (ss SymbolSet) tokenize(trans string, ipa bool) []posToken {
+	get := ss.Get
+	delim := ss.PhonemeDelimiter.String
+	t := ss.symbolTrie
+	if ipa {
+		get = ss.GetFromInternalIPA
+		delim = ss.PhonemeDelimiter.IPA.String
+		t = ss.ipaTrie
+	}
+
+	// spans of the input, without phoneme delimiters
+	var spans [][2]int
+	if delim == "" {
+		for i := 0; i < len(trans); {
+			n := t.longestMatch(trans[i:])
+			if n == 0 {
+				_, n = utf8.DecodeRuneInString(trans[i:])
+			}
+			spans = append(spans, [2]int{i, i + n})
+			i += n
+		}
+	} else {
+		var delims [][]int
+		if ipa {
+			for i := 0; i < len(trans); {
+				j := strings.Index(trans[i:], delim)
+				if j < 0 {
+					break
+				}
+				delims = append(delims, []int{i + j, i + j + len(delim)})
+				i += j + len(delim)
+			}
+		} else {
+			delims = ss.phonemeDelimiterRe.FindAllStringIndex(trans, -1)
+		}
+		start := 0
+		for _, d := range delims {
+			if d[0] > start {
+				spans = append(spans, [2]int{start, d[0]})
+			}
+			start = d[1]
+		}
+		if start < len(trans) {
+			spans = append(spans, [2]int{start, len(trans)})
+		}
+	}
+
+	var res []posToken
+	for _, span := range spans {
+		s := trans[span[0]:span[1]]
+		if sym, err := get(s); err == nil {
+			res = append(res, posToken{s: s, offset: span[0], cat: sym.Cat, known: true})
+			continue
+		}
+		// detach stress suffixes
+		var stress []posToken
+		end := len(s)
+		if !ipa {
+			for found := true; found; {
+				found = false
+				for _, st := range ss.stressSymbols {
+					if len(st.String) > 0 && len(st.String) < end && strings.HasSuffix(s[:end], st.String) {
+						end -= len(st.String)
+						stress = append([]posToken{{s: st.String, offset: span[0] + end, cat: Stress, known: true}}, stress...)
+						found = true
+						break
+					}
+				}
+				if _, err := get(s[:end]); err == nil {
+					break
+				}
+			}
+		}
+		if sym, err := get(s[:end]); err == nil && len(stress) > 0 {
+			res = append(res, posToken{s: s[:end], offset: span[0], cat: sym.Cat, known: true})
+			res = append(res, stress...)
+		} else {
+			res = append(res, posToken{s: s, offset: span[0]})
+		}
+	}
+	return res
+}
+
+// stressAfterNucleus returns true if stress symbols are placed after the syllabic phoneme they belong to
+func (ss SymbolSet) stressAfterNucleus() bool {
+	switch ss.StressPlacement {
+	case StressAfterNucleus, StressToneLetter:
+		return true
+	case StressUndefined:
+		return ss.Type == CMU
+	}
+	return false
+}
+
+// Validate checks the input transcription for unknown symbols, deprecated symbols, misplaced stress and empty syllables.
+// The diagnostics returned have the positions of the offending tokens in the input string.
+func (ss SymbolSet) Validate(trans string) []Diagnostic {
+	return ss.validate(trans, false)
+}
+
+// ValidateInternalIPA checks the input IPA transcription for unknown symbols, deprecated symbols, misplaced stress and empty syllables.
+// The input is normalized using NormalizeIPAInput before validation, but the diagnostics returned have the positions of the offending tokens in the input string.
+func (ss SymbolSet) ValidateInternalIPA(trans string) []Diagnostic {
+	normalized, segments := ss.normalizeIPAInputWithSegments(trans)
+	res := ss.validate(normalized, true)
+	for i, d := range res {
+		from, to := inputSpan(segments, d.ByteOffset, d.ByteOffset+len(d.Token))
+		if d.Token != "" {
+			res[i].Token = trans[from:to]
+		}
+		res[i].ByteOffset = from
+		res[i].RuneOffset = utf8.RuneCountInString(trans[:from])
+	}
+	return res
+}
+
+func (ss SymbolSet) validate(trans string, ipa bool) []Diagnostic {
+	var res []Diagnostic
+	tokens := ss.tokenize(trans, ipa)
+	add := func(t posToken, d Diagnostic) {
+		res = append(res, diagnosticAt(trans, t, d))
+	}
+	aliases := ss.symbolAliases
+	if ipa {
+		aliases = ss.ipaAliases
+	}
+
+	// unknown and deprecated symbols
+	for _, t := range tokens {
+		if !t.known {
+			d := UnknownSymbolDiagnostic(t.s)
+			d.Suggestion = ss.suggest(t.s, ipa)
+			add(t, d)
+		} else if a, ok := aliases[t.s]; ok && a.Deprecated {
+			add(t, DeprecatedSymbolDiagnostic(t.s, a.Canonical))
+		}
+	}
+
+	// misplaced stress and empty syllables, chunk by chunk
+	afterNucleus := !ipa && ss.stressAfterNucleus()
+	var chunk []posToken
+	var prevDelim *posToken
+	reported := make(map[int]bool)
+	checkChunk := func(delim *posToken) {
+		hasPhonemes := false
+		for _, t := range chunk {
+			if t.cat == Syllabic || t.cat == NonSyllabic || !t.known {
+				hasPhonemes = true
+			}
+		}
+		if !hasPhonemes {
+			// the empty syllable is reported at the delimiter following it, or at the preceding delimiter at the end of the transcription
+			if delim == nil {
+				delim = prevDelim
+			}
+			// delimiters next to pauses and intonation boundaries are not reported, since these need not have a syllable on both sides
+			if delim != nil && (isProsodicBoundary(delim.cat) || (prevDelim != nil && isProsodicBoundary(prevDelim.cat))) {
+				delim = nil
+			}
+			if delim != nil && !reported[delim.offset] {
+				add(*delim, EmptySyllableDiagnostic(delim.s))
+				reported[delim.offset] = true
+			}
+		}
+		for i, t := range chunk {
+			if t.cat != Stress {
+				continue
+			}
+			hasNucleus := false
+			if afterNucleus {
+				for _, prev := range chunk[:i] {
+					hasNucleus = hasNucleus || prev.cat == Syllabic
+				}
+			} else {
+				for _, next := range chunk[i+1:] {
+					hasNucleus = hasNucleus || next.cat == Syllabic
+				}
+			}
+			if !hasNucleus {
+				add(t, MisplacedStressDiagnostic(t.s, afterNucleus))
+			}
+		}
+		chunk = nil
+	}
+	for i, t := range tokens {
+		switch t.cat {
+		case SyllableDelimiter, MorphemeDelimiter, CompoundDelimiter, WordDelimiter, Pause, IntonationBoundary:
+			if t.known {
+				checkChunk(&tokens[i])
+				prevDelim = &tokens[i]
+				continue
+			}
+		}
+		chunk = append(chunk, t)
+	}
+	if len(tokens) > 0 {
+		checkChunk(nil)
+	}
+	return res
+}
+
+// suggest returns a suggested replacement for an unknown token: the token split into known symbols, or the most similar symbol
+func (ss SymbolSet) suggest(token string, ipa bool) string {
+	t := ss.symbolTrie
+	delim := ss.PhonemeDelimiter.String
+	if ipa {
+		t = ss.ipaTrie
+		delim = ss.PhonemeDelimiter.IPA.String
+	}
+	if delim != "" {
+		if splitted, unknown := t.split(token); len(unknown) == 0 && len(splitted) > 1 {
+			return strings.Join(splitted, delim)
+		}
+	}
+	best, bestDist := "", 3 // only suggest symbols within edit distance 2
+	for _, sym := range ss.allSymbols() {
+		s := sym.String
+		if ipa {
+			s = sym.IPA.String
+		}
+		if len(s) == 0 {
+			continue
+		}
+		// on equal distance, prefer the symbol with the longest common prefix
+		if dist := editDistance(token, s); dist < bestDist || (dist == bestDist && commonPrefixLen(token, s) > commonPrefixLen(token, best)) {
+			best, bestDist = s, dist
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between two strings, rune by rune
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
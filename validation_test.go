@@ -0,0 +1,127 @@
+package symbolset
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func diagnosticsString(diags []Diagnostic) string {
+	var res []string
+	for _, d := range diags {
+		res = append(res, strings.Join([]string{d.ErrorType, d.Token, strconv.Itoa(d.RuneOffset), d.Suggestion}, ":"))
+	}
+	return strings.Join(res, " | ")
+}
+
+func Test_Validate(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{`" a . b a`, ""},
+		{`" a . b a0`, "Unknown input symbol:a0:8:a"},
+		{`" a . ba`, "Unknown input symbol:ba:6:b a"},
+		{`a . " . b a`, "Empty syllable:.:6: | Misplaced stress:\":4:"},
+		{`. a`, "Empty syllable:.:0:"},
+		{`a b .`, "Empty syllable:.:4:"},
+		{`a . b a "`, "Misplaced stress:\":8:"},
+	}
+	for _, test := range tests {
+		result := diagnosticsString(ss.Validate(test.input))
+		if result != test.expect {
+			t.Errorf("/%s/: "+fsExp, test.input, test.expect, result)
+		}
+	}
+}
+
+func Test_Validate_Positions(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	diags := ss.ValidateInternalIPA("ˈbɑːkä")
+	if len(diags) != 1 {
+		t.Errorf("expected 1 diagnostic, found %v", diags)
+		return
+	}
+	d := diags[0]
+	if d.Token != "ä" || d.ByteOffset != 8 || d.RuneOffset != 5 || d.ErrorCode != ErrCodeUnknownInputSymbol {
+		t.Errorf("unexpected diagnostic %#v", d)
+	}
+}
+
+func Test_Validate_CMU(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if diags := ss.Validate("HH AH0 $ L OW1"); len(diags) != 1 || diags[0].Token != "AH0" {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+	if diags := ss.Validate("HH AH $ L OW1"); len(diags) != 0 {
+		t.Errorf("didn't expect diagnostics here : %v", diags)
+	}
+	diags := ss.Validate("HH AH $ 1 L OW")
+	if len(diags) != 1 || diags[0].ErrorCode != ErrCodeMisplacedStress || diags[0].ByteOffset != 8 {
+		t.Errorf("expected misplaced stress, found %v", diags)
+	}
+}
+
+func Test_Validate_Deprecated(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(aliasTestInput))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	diags := ss.Validate("s' i:")
+	if len(diags) != 1 || diags[0].ErrorCode != ErrCodeDeprecatedSymbol || diags[0].Suggestion != "s" {
+		t.Errorf("expected deprecated symbol, found %v", diags)
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b   string
+		expect int
+	}{
+		{"", "", 0},
+		{"a:", "a", 1},
+		{"kitten", "sitting", 3},
+		{"ɡɑː", "gɑ", 2},
+	} {
+		if result := editDistance(test.a, test.b); result != test.expect {
+			t.Errorf("%s/%s: "+fsExp, test.a, test.b, test.expect, result)
+		}
+	}
+}
+
+func Test_Validate_Pauses(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/cmn_x-sampa-tone.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{`m a _1 sil n i _3`, ""},
+		{`m a . sil . n i`, ""}, // no empty syllables next to the pause
+		{`sil " m a | n i ||`, ""},
+		{`" sil m a`, "Misplaced stress:\":0:"}, // stress is not moved across pauses
+		{`m a . . n i`, "Empty syllable:.:6:"},
+	}
+	for _, test := range tests {
+		result := diagnosticsString(ss.Validate(test.input))
+		if result != test.expect {
+			t.Errorf("/%s/: "+fsExp, test.input, test.expect, result)
+		}
+	}
+}
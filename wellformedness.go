@@ -0,0 +1,246 @@
+package symbolset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// phonotactic and prosodic well-formedness checks
+
+// Rule is a well-formedness rule for transcriptions, checked by SymbolSet.CheckWellFormedness
+type Rule int
+
+const (
+	// RuleSyllableNucleus: each syllable has exactly one syllabic nucleus (only checked if the symbol set has syllable delimiters)
+	RuleSyllableNucleus Rule = iota
+
+	// RuleSinglePrimaryStress: each word has at most one primary stress
+	RuleSinglePrimaryStress
+
+	// RuleStressPlacement: stress marks sit at syllable starts, or as declared by the symbol set's StressPlacement
+	RuleStressPlacement
+
+	// RuleNoDoubleDelimiters: delimiters are not doubled
+	RuleNoDoubleDelimiters
+
+	// RuleCompoundAtSyllableBoundary: compound delimiters do not sit inside syllables
+	RuleCompoundAtSyllableBoundary
+)
+
+var ruleNames = []string{"SyllableNucleus", "SinglePrimaryStress", "StressPlacement", "NoDoubleDelimiters", "CompoundAtSyllableBoundary"}
+
+// Rules lists all well-formedness rules
+var Rules = []Rule{RuleSyllableNucleus, RuleSinglePrimaryStress, RuleStressPlacement, RuleNoDoubleDelimiters, RuleCompoundAtSyllableBoundary}
+
+func (r Rule) String() string {
+	if r < 0 || int(r) >= len(ruleNames) {
+		return fmt.Sprintf("Rule(%d)", r)
+	}
+	return ruleNames[r]
+}
+
+// RuleFromString returns the rule with the given name (as returned by Rule.String)
+func RuleFromString(s string) (Rule, error) {
+	for i, name := range ruleNames {
+		if s == name {
+			return Rule(i), nil
+		}
+	}
+	return RuleSyllableNucleus, fmt.Errorf("invalid rule '%s' (expected one of %s)", s, strings.Join(ruleNames, ", "))
+}
+
+// Rules can be disabled for a symbol set using DISABLE_RULE lines in the .sym file, one rule per line, e.g.:
+//
+//	DISABLE_RULE	SinglePrimaryStress
+const disableRuleDirective = "DISABLE_RULE"
+
+func isDisableRuleLine(l string) bool {
+	return strings.HasPrefix(l, disableRuleDirective+"\t")
+}
+
+func parseDisableRuleLine(l string) (Rule, error) {
+	fs := strings.Split(l, "\t")
+	if len(fs) != 2 {
+		return RuleSyllableNucleus, fmt.Errorf("%s line must have 2 fields, found %s", disableRuleDirective, l)
+	}
+	return RuleFromString(strings.TrimSpace(fs[1]))
+}
+
+// RuleEnabled returns true if the rule is enabled for the symbol set (all rules are enabled, unless disabled using DisabledRules)
+func (ss SymbolSet) RuleEnabled(r Rule) bool {
+	for _, d := range ss.DisabledRules {
+		if d == r {
+			return false
+		}
+	}
+	return true
+}
+
+func (ss SymbolSet) isPrimaryStress(s string) bool {
+	sym, err := ss.Get(s)
+	return err == nil && sym.Cat == Stress && strings.HasPrefix(sym.IPA.String, ipaAccentI)
+}
+
+// CheckWellFormedness checks the input transcription against the well-formedness rules enabled for the symbol set.
+// The diagnostics returned have the positions of the offending tokens in the input string, sorted by position.
+// Unknown symbols are not reported (use SymbolSet.Validate).
+func (ss SymbolSet) CheckWellFormedness(trans string) []Diagnostic {
+	var res []Diagnostic
+	add := func(t posToken, d Diagnostic) {
+		res = append(res, diagnosticAt(trans, t, d))
+	}
+	tokens := ss.tokenize(trans, false)
+	isBoundary := func(t posToken) bool {
+		return t.known && (t.cat == SyllableDelimiter || t.cat == MorphemeDelimiter || t.cat == CompoundDelimiter || t.cat == WordDelimiter)
+	}
+
+	if ss.RuleEnabled(RuleNoDoubleDelimiters) {
+		for i := 1; i < len(tokens); i++ {
+			if isBoundary(tokens[i]) && isBoundary(tokens[i-1]) {
+				add(tokens[i], DoubleDelimiterDiagnostic(tokens[i].s))
+			}
+		}
+		if ss.PhonemeDelimiter.String != "" {
+			delims := ss.phonemeDelimiterRe.FindAllStringIndex(trans, -1)
+			for i := 1; i < len(delims); i++ {
+				if delims[i][0] == delims[i-1][1] {
+					t := posToken{s: trans[delims[i][0]:delims[i][1]], offset: delims[i][0]}
+					add(t, DoubleDelimiterDiagnostic(t.s))
+				}
+			}
+		}
+	}
+
+	hasSyllDelim := ss.filters.hasSyllDelim
+	placement := ss.StressPlacement
+	if placement == StressUndefined && ss.Type == CMU {
+		placement = StressAfterNucleus
+	}
+
+	// split into words, and each word into syllables (morpheme delimiters are ignored, since they need not align with syllable boundaries)
+	// pauses and intonation boundaries are treated as word boundaries
+	var words [][]posToken
+	var word []posToken
+	for _, t := range tokens {
+		if t.known && (t.cat == WordDelimiter || isProsodicBoundary(t.cat)) {
+			words = append(words, word)
+			word = nil
+			continue
+		}
+		word = append(word, t)
+	}
+	words = append(words, word)
+
+	for _, word := range words {
+		if ss.RuleEnabled(RuleSinglePrimaryStress) {
+			nPrimary := 0
+			for _, t := range word {
+				if t.known && ss.isPrimaryStress(t.s) {
+					nPrimary++
+					if nPrimary > 1 {
+						add(t, MultiplePrimaryStressDiagnostic(t.s))
+					}
+				}
+			}
+		}
+
+		type syllable struct {
+			tokens []posToken
+			// compound delimiters before and after the syllable, if any
+			compoundBefore, compoundAfter *posToken
+		}
+		var sylls []syllable
+		var syll syllable
+		for i, t := range word {
+			if t.known && (t.cat == SyllableDelimiter || t.cat == CompoundDelimiter) {
+				if t.cat == CompoundDelimiter {
+					syll.compoundAfter = &word[i]
+				}
+				sylls = append(sylls, syll)
+				syll = syllable{}
+				if t.cat == CompoundDelimiter {
+					syll.compoundBefore = &word[i]
+				}
+				continue
+			}
+			if t.known && t.cat == MorphemeDelimiter {
+				continue
+			}
+			syll.tokens = append(syll.tokens, t)
+		}
+		sylls = append(sylls, syll)
+
+		for _, syll := range sylls {
+			var nuclei []posToken
+			hasPhonemes := false
+			for _, t := range syll.tokens {
+				if t.cat == Syllabic {
+					nuclei = append(nuclei, t)
+				}
+				if t.cat == Syllabic || t.cat == NonSyllabic || !t.known {
+					hasPhonemes = true
+				}
+			}
+			if !hasPhonemes {
+				continue
+			}
+
+			if len(nuclei) == 0 && ss.RuleEnabled(RuleCompoundAtSyllableBoundary) && (syll.compoundBefore != nil || syll.compoundAfter != nil) {
+				compound := syll.compoundBefore
+				if compound == nil {
+					compound = syll.compoundAfter
+				}
+				add(*compound, CompoundInsideSyllableDiagnostic(compound.s))
+			} else if hasSyllDelim && ss.RuleEnabled(RuleSyllableNucleus) {
+				if len(nuclei) == 0 {
+					add(syll.tokens[0], SyllableNucleusDiagnostic(0))
+				} else if len(nuclei) > 1 {
+					add(nuclei[1], SyllableNucleusDiagnostic(len(nuclei)))
+				}
+			}
+
+			if ss.RuleEnabled(RuleStressPlacement) && (hasSyllDelim || placement == StressBeforeNucleus || placement == StressAfterNucleus) {
+				for i, t := range syll.tokens {
+					if t.cat == Stress && !stressPlacementOK(syll.tokens, i, placement) {
+						add(t, StressPlacementDiagnostic(t.s, placement))
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ByteOffset < res[j].ByteOffset })
+	return res
+}
+
+// stressPlacementOK checks the position of the stress symbol at index i in the syllable
+func stressPlacementOK(syll []posToken, i int, placement StressPlacement) bool {
+	// the closest phonemes before and after the stress symbol (other stress symbols, tone symbols, length marks and diacritics are skipped)
+	skip := func(t posToken) bool {
+		return t.known && (t.cat == Stress || t.cat == Tone || attachesToPhoneme(t.cat))
+	}
+	var prev, next *posToken
+	for j := i - 1; j >= 0 && prev == nil; j-- {
+		if !skip(syll[j]) {
+			prev = &syll[j]
+		}
+	}
+	for j := i + 1; j < len(syll) && next == nil; j++ {
+		if !skip(syll[j]) {
+			next = &syll[j]
+		}
+	}
+	switch placement {
+	case StressBeforeSyllable:
+		return prev == nil
+	case StressBeforeNucleus:
+		return next != nil && next.cat == Syllabic
+	case StressAfterNucleus:
+		return prev != nil && prev.cat == Syllabic
+	case StressToneLetter:
+		return next == nil
+	}
+	// undefined stress placement: at syllable start, or before the nucleus
+	return prev == nil || (next != nil && next.cat == Syllabic)
+}
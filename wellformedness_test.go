@@ -0,0 +1,130 @@
+package symbolset
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var wellFormednessTestInput = strings.Join([]string{
+	header,
+	"sil	i:	iː	U+0069U+02D0	Syllabic",
+	"matt	a	a	U+0061	Syllabic",
+	"bok	b	b	U+0062	NonSyllabic",
+	"tok	t	t	U+0074	NonSyllabic",
+	"syllable delimiter	.	.	U+002E	SyllableDelimiter",
+	"compound delimiter	-	-	U+002D	CompoundDelimiter",
+	"word delimiter	#	‿	U+203F	WordDelimiter",
+	"accent I	\"	ˈ	U+02C8	Stress",
+	"secondary stress	%	ˌ	U+02CC	Stress",
+	"phoneme delimiter	 			PhonemeDelimiter",
+}, "\n") + "\n"
+
+func Test_CheckWellFormedness(t *testing.T) {
+	ss, err := ReadSymbolSet("test", strings.NewReader(wellFormednessTestInput))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{`" b a . t i:`, ""},
+		{`b " a . t i:`, ""}, // undefined stress placement: before the nucleus is ok
+		{`" b a - % t i: # " b a`, ""},
+		{`" b a t i:`, "Syllable nucleus:i::8:"},
+		{`" b a . t`, "Syllable nucleus:t:8:"},
+		{`" b a . " t i:`, "Multiple primary stress:\":8:"},
+		{`b a " . t i:`, "Misplaced stress:\":4:"},
+		{`b a . . t i:`, "Double delimiter:.:6:"},
+		{`b a  . t i:`, "Double delimiter: :4:"},
+		{`b a - t`, "Compound delimiter inside syllable:-:4:"},
+		{`b a t - t i:`, ""},
+	}
+	for _, test := range tests {
+		result := diagnosticsString(ss.CheckWellFormedness(test.input))
+		if result != test.expect {
+			t.Errorf("/%s/: "+fsExp, test.input, test.expect, result)
+		}
+	}
+}
+
+func Test_CheckWellFormedness_StressPlacement(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/en-us_cmu.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if diags := ss.CheckWellFormedness("HH AH $ L OW1"); len(diags) != 0 {
+		t.Errorf("didn't expect diagnostics here : %v", diags)
+	}
+	if diags := ss.CheckWellFormedness("HH AH $ L 1 OW"); len(diags) != 1 || diags[0].ErrorCode != ErrCodeMisplacedStress {
+		t.Errorf("expected misplaced stress, found %v", diags)
+	}
+}
+
+func Test_CheckWellFormedness_EmptySyllableDelimiter(t *testing.T) {
+	// a syllable delimiter without a symbol string cannot be used in transcriptions, so syllables are not checked
+	input := strings.Replace(wellFormednessTestInput, "syllable delimiter	.	.	U+002E", "syllable delimiter		.	U+002E", 1)
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	for _, input := range []string{`" b a t i:`, `b a " t i:`} {
+		if diags := ss.CheckWellFormedness(input); len(diags) != 0 {
+			t.Errorf("/%s/: didn't expect diagnostics here : %v", input, diags)
+		}
+	}
+}
+
+func Test_DisabledRules(t *testing.T) {
+	input := strings.Replace(wellFormednessTestInput, "\n", "\nDISABLE_RULE\tSyllableNucleus\n", 1)
+	ss, err := ReadSymbolSet("test", strings.NewReader(input))
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if ss.RuleEnabled(RuleSyllableNucleus) || !ss.RuleEnabled(RuleStressPlacement) {
+		t.Errorf("unexpected rules %v", ss.DisabledRules)
+	}
+	if diags := ss.CheckWellFormedness(`" b a t i:`); len(diags) != 0 {
+		t.Errorf("didn't expect diagnostics here : %v", diags)
+	}
+
+	var buf bytes.Buffer
+	if err := ss.WriteSym(&buf); err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+	}
+	if buf.String() != input {
+		t.Errorf(fsExp, input, buf.String())
+	}
+
+	if _, err := ReadSymbolSet("test", strings.NewReader(strings.Replace(input, "SyllableNucleus", "Nucleus", 1))); err == nil {
+		t.Errorf("expected error for invalid rule")
+	}
+}
+
+func Test_CheckWellFormedness_Pauses(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/cmn_x-sampa-tone.sym")
+	if err != nil {
+		t.Fatalf("didn't expect error here : %v", err)
+	}
+	var tests = []struct {
+		input  string
+		expect string
+	}{
+		{`" m a _1 sil " n i _3`, ""}, // pauses separate words
+		{`" m a _1 || " n i _3`, ""},
+		{`" m a _1 . " n i _3`, "Multiple primary stress:\":11:"},
+		{`" p _h a : _4`, ""},
+		{`p _h " a : _4`, "Misplaced stress:\":5:"},
+	}
+	for _, test := range tests {
+		result := diagnosticsString(ss.CheckWellFormedness(test.input))
+		if result != test.expect {
+			t.Errorf("/%s/: "+fsExp, test.input, test.expect, result)
+		}
+	}
+}
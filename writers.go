@@ -0,0 +1,230 @@
+package symbolset
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// functions for writing symbol sets to file
+
+type symLineKind int
+
+const (
+	commentLine symLineKind = iota // comments and blank lines
+	symbolLine
+	testLine
+	metadataLine
+	stressPlacementLine
+	aliasLine
+	disableRuleLine
+	normalizationLine
+	modifierLine
+)
+
+// symLine is a line in a .sym file, used to keep the line order when a symbol set is written back to file
+type symLine struct {
+	line  int // line number in the .sym file
+	kind  symLineKind
+	text  string // comment text, or metadata directive name
+	index int    // index of the symbol, test, alias, modifier or rule line
+}
+
+func (ss SymbolSet) hasFeatures() bool {
+	for _, s := range ss.Symbols {
+		if len(s.Features) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (ss SymbolSet) metadataValue(key string) string {
+	switch key {
+	case "TYPE":
+		if ss.Type == InternalIPA {
+			return ""
+		}
+		return ss.Type.String()
+	case "LANGUAGE":
+		return ss.Language
+	case "VERSION":
+		return ss.Version
+	case "DESCRIPTION":
+		return ss.Description
+	case "LICENSE":
+		return ss.License
+	case "SOURCE":
+		return ss.Source
+	}
+	return ""
+}
+
+func (ss SymbolSet) symbolFields(sym Symbol, withFeatures bool) []string {
+	res := []string{sym.Desc, sym.String, sym.IPA.String, sym.IPA.Unicode, sym.Cat.String()}
+	if withFeatures {
+		res = append(res, string(sym.Features))
+	}
+	return res
+}
+
+// hasValidLayout checks if the line order from the .sym file is still valid for the symbol set
+func (ss SymbolSet) hasValidLayout() bool {
+	if len(ss.layout) == 0 {
+		return false
+	}
+	nSymbols, nTests, nAliases, nModifiers, nRules := 0, 0, 0, 0, 0
+	for _, l := range ss.layout {
+		switch l.kind {
+		case symbolLine:
+			nSymbols++
+		case testLine:
+			nTests++
+		case aliasLine:
+			nAliases++
+		case modifierLine:
+			nModifiers++
+		case disableRuleLine:
+			nRules++
+		}
+	}
+	return nSymbols == len(ss.Symbols) && nTests == len(ss.testLines) && nAliases == len(ss.Aliases) && nModifiers == len(ss.Modifiers) && nRules == len(ss.DisabledRules)
+}
+
+// WriteSym writes the symbol set to the writer, in .sym file format.
+// If the symbol set was loaded from a .sym file, comments, tests, aliases, modifiers and directives are written in the same order as in the original file.
+// Otherwise, directives are written first, followed by the symbols, the modifiers, the aliases and the tests. Composed symbols (see Modifier) are not written.
+func (ss SymbolSet) WriteSym(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	withFeatures := ss.hasFeatures()
+	var lines []string
+	if withFeatures {
+		lines = append(lines, headerWithFeatures)
+	} else {
+		lines = append(lines, header)
+	}
+
+	writeSymbol := func(i int) {
+		sym := ss.Symbols[i]
+		// the features column is optional for symbols without features
+		lines = append(lines, strings.Join(ss.symbolFields(sym, len(sym.Features) > 0), "\t"))
+	}
+	writeMetadata := func(key string) {
+		if value := ss.metadataValue(key); len(value) > 0 {
+			lines = append(lines, key+"\t"+value)
+		}
+	}
+	writeStressPlacement := func() {
+		if ss.StressPlacement != StressUndefined {
+			lines = append(lines, stressPlacementDirective+"\t"+ss.StressPlacement.String())
+		}
+	}
+
+	// a declared normalization line is written also for the default form
+	writeNormalization := func(declared bool) {
+		if declared || ss.NormalizationForm != FormNone {
+			lines = append(lines, normalizationDirective+"\t"+ss.NormalizationForm.String())
+		}
+	}
+
+	if ss.hasValidLayout() {
+		// directives that have been added since the symbol set was loaded
+		written := make(map[string]bool)
+		hasStressPlacement := false
+		hasNormalization := false
+		for _, l := range ss.layout {
+			if l.kind == metadataLine {
+				written[l.text] = true
+			} else if l.kind == stressPlacementLine {
+				hasStressPlacement = true
+			} else if l.kind == normalizationLine {
+				hasNormalization = true
+			}
+		}
+		for _, key := range metadataDirectives {
+			if key == "TYPE" && ss.Type == symbolSetTypeFromString(ss.Name) {
+				continue
+			}
+			if !written[key] {
+				writeMetadata(key)
+			}
+		}
+		if !hasStressPlacement {
+			writeStressPlacement()
+		}
+		if !hasNormalization {
+			writeNormalization(false)
+		}
+
+		for _, l := range ss.layout {
+			switch l.kind {
+			case commentLine:
+				lines = append(lines, l.text)
+			case symbolLine:
+				writeSymbol(l.index)
+			case testLine:
+				lines = append(lines, ss.testLines[l.index])
+			case aliasLine:
+				lines = append(lines, ss.Aliases[l.index].String())
+			case modifierLine:
+				lines = append(lines, ss.Modifiers[l.index].line())
+			case disableRuleLine:
+				lines = append(lines, disableRuleDirective+"\t"+ss.DisabledRules[l.index].String())
+			case metadataLine:
+				writeMetadata(l.text)
+			case stressPlacementLine:
+				writeStressPlacement()
+			case normalizationLine:
+				writeNormalization(true)
+			}
+		}
+	} else {
+		for _, key := range metadataDirectives {
+			writeMetadata(key)
+		}
+		writeStressPlacement()
+		writeNormalization(false)
+		for _, r := range ss.DisabledRules {
+			lines = append(lines, disableRuleDirective+"\t"+r.String())
+		}
+		for i := range ss.Symbols {
+			writeSymbol(i)
+		}
+		for _, m := range ss.Modifiers {
+			lines = append(lines, m.line())
+		}
+		for _, a := range ss.Aliases {
+			lines = append(lines, a.String())
+		}
+		lines = append(lines, ss.testLines...)
+	}
+
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(bw, l); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteCSV writes the symbols of the symbol set to the writer, in CSV format, with the column names on the first line
+func (ss SymbolSet) WriteCSV(w io.Writer) error {
+	withFeatures := ss.hasFeatures()
+	cw := csv.NewWriter(w)
+	columns := strings.Split(header, "\t")
+	if withFeatures {
+		columns = strings.Split(headerWithFeatures, "\t")
+	}
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, sym := range ss.Symbols {
+		if err := cw.Write(ss.symbolFields(sym, withFeatures)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
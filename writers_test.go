@@ -0,0 +1,191 @@
+package symbolset
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testEqSymbolSets(t *testing.T, expect SymbolSet, result SymbolSet) {
+	if expect.Name != result.Name {
+		t.Errorf(fsExp, expect.Name, result.Name)
+	}
+	if expect.Type != result.Type {
+		t.Errorf(fsExp, expect.Type, result.Type)
+	}
+	if expect.StressPlacement != result.StressPlacement {
+		t.Errorf(fsExp, expect.StressPlacement, result.StressPlacement)
+	}
+	for _, key := range metadataDirectives {
+		if expect.metadataValue(key) != result.metadataValue(key) {
+			t.Errorf("%s: "+fsExp, key, expect.metadataValue(key), result.metadataValue(key))
+		}
+	}
+	testEqSymbols(t, expect.Symbols, result.Symbols)
+	testEqStrings(t, expect.testLines, result.testLines)
+}
+
+func Test_WriteSym_RoundTrip(t *testing.T) {
+	fNames, err := filepath.Glob("test_data/*.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	for _, fName := range fNames {
+		ss, err := LoadSymbolSet(fName)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		var buf bytes.Buffer
+		err = ss.WriteSym(&buf)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+
+		orig, err := os.ReadFile(fName)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		if buf.String() != string(orig) {
+			t.Errorf("WriteSym output differs from %s:\n%s", fName, buf.String())
+		}
+
+		outFile := filepath.Join(t.TempDir(), filepath.Base(fName))
+		err = os.WriteFile(outFile, buf.Bytes(), 0600)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		ss2, err := LoadSymbolSetWithName(ss.Name, outFile)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		testEqSymbolSets(t, ss, ss2)
+	}
+}
+
+func Test_WriteSym_NewSymbolSet(t *testing.T) {
+	symbols := []Symbol{
+		{String: "a", Cat: Syllabic, Desc: "a", IPA: IPASymbol{String: "a", Unicode: "U+0061"}, Features: "+syll"},
+		{String: "t", Cat: NonSyllabic, Desc: "t", IPA: IPASymbol{String: "t", Unicode: "U+0074"}, Features: "-syll,-voice"},
+		{String: " ", Cat: PhonemeDelimiter, Desc: "phoneme delimiter", IPA: IPASymbol{String: "", Unicode: ""}},
+	}
+	ss, err := NewSymbolSetWithTests("test", symbols, []string{"TEST	ACCEPT	SYMBOLS	t a t"}, true)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	ss.Language = "sv-SE"
+	ss.StressPlacement = StressBeforeSyllable
+
+	var buf bytes.Buffer
+	err = ss.WriteSym(&buf)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	expect := strings.Join([]string{
+		headerWithFeatures,
+		"TYPE	Other",
+		"LANGUAGE	sv-SE",
+		"STRESS_PLACEMENT	BeforeSyllable",
+		"a	a	a	U+0061	Syllabic	+syll",
+		"t	t	t	U+0074	NonSyllabic	-syll,-voice",
+		"phoneme delimiter	 			PhonemeDelimiter",
+		"TEST	ACCEPT	SYMBOLS	t a t",
+	}, "\n") + "\n"
+	if buf.String() != expect {
+		t.Errorf(fsExp, expect, buf.String())
+	}
+}
+
+func Test_WriteCSV(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa-features.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	var buf bytes.Buffer
+	err = ss.WriteCSV(&buf)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	if len(records) != len(ss.Symbols)+1 {
+		t.Errorf("Expected %d records, got %d", len(ss.Symbols)+1, len(records))
+		return
+	}
+	testEqStrings(t, strings.Split(headerWithFeatures, "\t"), records[0])
+	for i, sym := range ss.Symbols {
+		testEqStrings(t, []string{sym.Desc, sym.String, sym.IPA.String, sym.IPA.Unicode, sym.Cat.String(), string(sym.Features)}, records[i+1])
+	}
+}
+
+func Test_JSON_RoundTrip(t *testing.T) {
+	for _, fName := range []string{"test_data/sv-se_ws-sampa-features.sym", "test_data/sv-se_nst-xsampa.sym", "test_data/en-us_cmu.sym"} {
+		ss, err := LoadSymbolSet(fName)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		var buf bytes.Buffer
+		err = ss.WriteJSON(&buf)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		ss2, err := ReadSymbolSetJSON(&buf)
+		if err != nil {
+			t.Errorf("didn't expect error here : %v", err)
+			continue
+		}
+		testEqSymbolSets(t, ss, ss2)
+		if !reflect.DeepEqual(ss.ToJSON(), ss2.ToJSON()) {
+			t.Errorf(fsExp, ss.ToJSON(), ss2.ToJSON())
+		}
+	}
+}
+
+func Test_LoadSymbolSetJSON(t *testing.T) {
+	ss, err := LoadSymbolSet("test_data/sv-se_ws-sampa.sym")
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	fName := filepath.Join(t.TempDir(), "sv-se_ws-sampa.json")
+	fh, err := os.Create(fName)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	err = ss.WriteJSON(fh)
+	fh.Close()
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	ss2, err := LoadSymbolSetJSON(fName)
+	if err != nil {
+		t.Errorf("didn't expect error here : %v", err)
+		return
+	}
+	testEqSymbolSets(t, ss, ss2)
+
+	_, err = ReadSymbolSetJSON(strings.NewReader(`{"Name": "test", "Type": "SAMPA", "Symbols": [{"Symbol": "a", "Cat": "Vowel"}]}`))
+	if err == nil {
+		t.Errorf("Expected error for invalid symbol category")
+	}
+}